@@ -0,0 +1,366 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	osx "os"
+	sts "strings"
+	syn "sync"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func AuditFileClass() AuditFileClassLike {
+	return auditFileClass()
+}
+
+// Constructor Methods
+
+func (c *auditFileClass_) AuditFile(
+	notary DigitalNotaryLike,
+	directory string,
+) AuditFileLike {
+	if uti.IsUndefined(notary) {
+		panic("The \"notary\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(directory) {
+		panic("The \"directory\" attribute is required by this class.")
+	}
+	uti.MakeDirectory(directory)
+	var filename = directory + "/AuditLog.bali"
+	var instance = &auditFile_{
+		// Initialize the instance attributes.
+		notary_:   notary,
+		filename_: filename,
+		tag_:      doc.Tag(),
+	}
+	if uti.PathExists(filename) {
+		instance.readEntries()
+	}
+	if len(instance.entries_) > 0 {
+		instance.tag_ = instance.entries_[0].GetContent().GetTag()
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *auditFile_) GetClass() AuditFileClassLike {
+	return auditFileClass()
+}
+
+func (v *auditFile_) VerifyEntries(
+	certificates []com.DocumentLike,
+	repository Resolving,
+) []string {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to verify the audit log",
+	)
+
+	v.mutex_.Lock()
+	defer v.mutex_.Unlock()
+	var violations []string
+	for index, entry := range v.entries_ {
+		var content = entry.GetContent()
+		var version = content.GetVersion()
+		if !entry.IsNotarized() {
+			violations = append(
+				violations,
+				fmt.Sprintf("Entry %s has not been notarized.", version.AsSource()),
+			)
+		} else {
+			var violation = v.verifySeal(entry, certificates, repository)
+			if len(violation) > 0 {
+				violations = append(violations, violation)
+			}
+		}
+		var previous = content.GetOptionalPrevious()
+		if index == 0 {
+			if uti.IsDefined(previous) {
+				violations = append(
+					violations,
+					fmt.Sprintf("The first entry %s cites a previous entry.", version.AsSource()),
+				)
+			}
+			continue
+		}
+
+		// Each entry must cite the entry that precedes it.
+		var prior = v.entries_[index-1]
+		var priorVersion = prior.GetContent().GetVersion()
+		if !doc.VersionClass().IsValidNextVersion(priorVersion, version) {
+			violations = append(
+				violations,
+				fmt.Sprintf(
+					"Entry %s does not follow entry %s.",
+					version.AsSource(),
+					priorVersion.AsSource(),
+				),
+			)
+		}
+		if uti.IsUndefined(previous) ||
+			!v.notary_.CitationMatches(com.CitationClass().CitationFromResource(previous), prior) {
+			violations = append(
+				violations,
+				fmt.Sprintf(
+					"Entry %s does not cite the digest of entry %s.",
+					version.AsSource(),
+					priorVersion.AsSource(),
+				),
+			)
+		}
+	}
+	return violations
+}
+
+// Attribute Methods
+
+func (v *auditFile_) GetEntries() []com.DocumentLike {
+	v.mutex_.Lock()
+	defer v.mutex_.Unlock()
+	return append([]com.DocumentLike{}, v.entries_...)
+}
+
+// AuditSink Methods
+
+func (v *auditFile_) RecordEvent(
+	operation string,
+	optionalSubject com.CitationLike,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to record an audit event",
+	)
+
+	// The notarization of each entry is itself an event whose subject is the
+	// entry, which must not be recorded.
+	if uti.IsDefined(optionalSubject) &&
+		optionalSubject.GetTag().AsSource() == v.tag_.AsSource() {
+		return
+	}
+	v.mutex_.Lock()
+	defer v.mutex_.Unlock()
+
+	// Each entry is the next version of the previous entry.
+	var tag = v.tag_
+	var version = doc.Version()
+	var previous doc.ResourceLike
+	var count = len(v.entries_)
+	if count > 0 {
+		var last = v.entries_[count-1]
+		var content = last.GetContent()
+		version = doc.VersionClass().GetNextVersion(content.GetVersion(), 0)
+		previous = v.notary_.CiteDocument(last).AsResource()
+	}
+
+	// Create and notarize the entry.  The attribute values are set directly so
+	// that the operation cannot change the structure of the entry.
+	var entity = doc.ParseComponent(`[
+    $operation: ""
+    $subject: none
+    $timestamp: ` + doc.Moment().AsSource() + `
+]`)
+	entity.SetSubcomponent(
+		doc.QuoteClass().Quote([]rune(operation)),
+		doc.Symbol("$operation"),
+	)
+	if uti.IsDefined(optionalSubject) {
+		entity.SetSubcomponent(
+			optionalSubject.AsResource(),
+			doc.Symbol("$subject"),
+		)
+	}
+	var content = com.ContentClass().Content(
+		entity,
+		doc.Name("/bali/types/notary/AuditEntry/v3"),
+		tag,
+		version,
+		doc.Name("/bali/permissions/Private/v3"),
+		previous,
+	)
+	var entry = com.DocumentClass().Document(content)
+	v.notary_.NotarizeDocument(entry)
+
+	// Append the entry to the end of the log.
+	v.writeEntry(entry)
+	v.entries_ = append(v.entries_, entry)
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *auditFile_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"AuditFile: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *auditFile_) parseEntry(
+	source string,
+) (
+	entry com.DocumentLike,
+) {
+	// A partial entry results in an undefined entry.
+	defer func() {
+		if e := recover(); e != nil {
+			entry = nil
+		}
+	}()
+	entry = com.DocumentClass().DocumentFromSource(source)
+	return
+}
+
+func (v *auditFile_) readEntries() {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to read in the audit log",
+	)
+
+	// The entries are separated by blank lines, but an entry may itself contain
+	// blank lines (e.g. within a narrative), so the parts of an entry are joined
+	// back together until they form a complete document.
+	var source = uti.ReadFile(v.filename_)
+	var partial string
+	for _, part := range sts.Split(source, "\n\n") {
+		if len(partial) > 0 {
+			part = partial + "\n\n" + part
+		}
+		if len(sts.TrimSpace(part)) == 0 {
+			continue
+		}
+		var entry = v.parseEntry(part)
+		if uti.IsUndefined(entry) {
+			partial = part
+			continue
+		}
+		partial = ""
+		v.entries_ = append(v.entries_, entry)
+	}
+	if len(partial) > 0 {
+		panic("The audit log ends with an incomplete entry.")
+	}
+}
+
+func (v *auditFile_) verifySeal(
+	entry com.DocumentLike,
+	certificates []com.DocumentLike,
+	repository Resolving,
+) (
+	violation string,
+) {
+	// The entry must be sealed by a valid version of a trusted certificate.
+	var version = entry.GetContent().GetVersion().AsSource()
+	defer func() {
+		if e := recover(); e != nil {
+			violation = fmt.Sprintf(
+				"The seal on entry %s could not be verified: %v",
+				version,
+				e,
+			)
+		}
+	}()
+	var citation = entry.GetNotaryCitation()
+	if uti.IsUndefined(citation) {
+		return fmt.Sprintf("Entry %s does not cite its certificate.", version)
+	}
+	var certificate = repository.RetrieveDocument(citation)
+	if uti.IsUndefined(certificate) ||
+		!v.notary_.CitationMatches(citation, certificate) {
+		return fmt.Sprintf("The certificate for entry %s could not be retrieved.", version)
+	}
+	var trusted bool
+	for _, root := range certificates {
+		if v.notary_.CertificateMatches(certificate, root, repository) {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return fmt.Sprintf("The certificate for entry %s is not trusted.", version)
+	}
+	if !v.notary_.SealMatches(entry, certificate) {
+		return fmt.Sprintf("The seal on entry %s does not match its certificate.", version)
+	}
+	return
+}
+
+func (v *auditFile_) writeEntry(
+	entry com.DocumentLike,
+) {
+	// The log is only ever appended to.
+	var file, err = osx.OpenFile(
+		v.filename_,
+		osx.O_APPEND|osx.O_CREATE|osx.O_WRONLY,
+		0644,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+	_, err = file.WriteString(entry.AsSource() + "\n")
+	if err != nil {
+		panic(err)
+	}
+	err = file.Sync()
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Instance Structure
+
+type auditFile_ struct {
+	// Declare the instance attributes.
+	notary_   DigitalNotaryLike
+	filename_ string
+	tag_      doc.TagLike
+	entries_  []com.DocumentLike
+	mutex_    syn.Mutex
+}
+
+// Class Structure
+
+type auditFileClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func auditFileClass() *auditFileClass_ {
+	return auditFileClassReference_
+}
+
+var auditFileClassReference_ = &auditFileClass_{
+	// Initialize the class constants.
+}
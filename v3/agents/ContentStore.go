@@ -0,0 +1,451 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	hex "encoding/hex"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	reg "regexp"
+	sli "slices"
+	sts "strings"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func ContentStoreClass() ContentStoreClassLike {
+	return contentStoreClass()
+}
+
+// Constructor Methods
+
+func (c *contentStoreClass_) ContentStore(
+	ssm Trusted,
+	directory string,
+) ContentStoreLike {
+	if uti.IsUndefined(ssm) {
+		panic("The \"ssm\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(directory) {
+		panic("The \"directory\" attribute is required by this class.")
+	}
+
+	// Each digest algorithm has its own namespace within the store.
+	var namespace = directory + "/" + ssm.GetDigestAlgorithm()
+	uti.MakeDirectory(namespace)
+	var instance = &contentStore_{
+		// Initialize the instance attributes.
+		ssm_:       ssm,
+		namespace_: namespace,
+		versions_:  make(map[string][]string),
+		aliases_:   make(map[string]string),
+	}
+	instance.readIndex()
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *contentStoreClass_) References(
+	source string,
+) []com.CitationLike {
+	// Any citation found in a document refers to another document, whether it
+	// is embedded like the certificate citation of a notary or is a resource
+	// like a previous version or a tree head.
+	var citations []com.CitationLike
+	for _, match := range c.citation_.FindAllString(source, -1) {
+		var citation = c.parseCitation(match)
+		if uti.IsDefined(citation) {
+			citations = append(citations, citation)
+		}
+	}
+	for _, match := range c.resource_.FindAllString(source, -1) {
+		var citation = c.parseCitation(match)
+		if uti.IsDefined(citation) {
+			citations = append(citations, citation)
+		}
+	}
+	return citations
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *contentStore_) GetClass() ContentStoreClassLike {
+	return contentStoreClass()
+}
+
+func (v *contentStore_) StoreDocument(
+	document com.DocumentLike,
+) com.CitationLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to store a document",
+	)
+
+	if uti.IsUndefined(document) {
+		panic("The \"document\" attribute is required by this method.")
+	}
+	var digest = v.ssm_.DigestBytes(document.AsCanonical())
+	var key = hex.EncodeToString(digest)
+	var content = document.GetContent()
+
+	// Identical documents are only ever stored once.
+	var filename = v.filename(key)
+	if !uti.PathExists(filename) {
+		var journal = filename + ".journal"
+		uti.WriteFile(journal, document.AsSource())
+		uti.RenamePath(journal, filename)
+		v.indexDocument(key, document)
+	}
+	return com.CitationClass().Citation(
+		content.GetTag(),
+		content.GetVersion(),
+		doc.Quote(`"`+v.ssm_.GetDigestAlgorithm()+`"`),
+		doc.Binary(digest),
+	)
+}
+
+func (v *contentStore_) CollectGarbage(
+	roots []com.CitationLike,
+) uint {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to collect the garbage in the store",
+	)
+
+	// Without any roots every entry would be removed.
+	if len(roots) == 0 {
+		panic("The \"roots\" attribute is required by this method.")
+	}
+
+	// Mark each entry that can be reached from the roots by following the
+	// citations found in each reachable document.
+	var marked = make(map[string]bool)
+	var pending = append([]com.CitationLike{}, roots...)
+	for len(pending) > 0 {
+		var citation = pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		var key, ok = v.key(citation)
+		if !ok || marked[key] || !uti.PathExists(v.filename(key)) {
+			continue
+		}
+		marked[key] = true
+		var source = uti.ReadFile(v.filename(key))
+		pending = append(pending, contentStoreClass().References(source)...)
+	}
+
+	// Sweep away all entries that were not marked.
+	var count uint
+	for tag, keys := range v.versions_ {
+		var remaining []string
+		for _, key := range keys {
+			if marked[key] {
+				remaining = append(remaining, key)
+				continue
+			}
+			uti.RemovePath(v.filename(key))
+			count++
+		}
+		if len(remaining) == 0 {
+			delete(v.versions_, tag)
+			continue
+		}
+		v.versions_[tag] = remaining
+	}
+	for _, key := range v.unindexed() {
+		if !marked[key] {
+			uti.RemovePath(v.filename(key))
+			count++
+		}
+	}
+	for alias, key := range v.aliases_ {
+		if !marked[key] {
+			delete(v.aliases_, alias)
+		}
+	}
+	return count
+}
+
+func (v *contentStore_) ScrubStore() []string {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to scrub the store",
+	)
+
+	// Each entry must still parse and match the digest it is stored under.
+	var violations []string
+	for _, filename := range uti.ReadDirectory(v.namespace_) {
+		var key, found = sts.CutSuffix(filename, ".bali")
+		if !found {
+			continue
+		}
+		var document = v.parseDocument(uti.ReadFile(v.filename(key)))
+		if uti.IsUndefined(document) {
+			violations = append(
+				violations,
+				fmt.Sprintf("The entry %s is not a valid document.", key),
+			)
+			continue
+		}
+		var digest = v.ssm_.DigestBytes(document.AsCanonical())
+		if hex.EncodeToString(digest) != key {
+			violations = append(
+				violations,
+				fmt.Sprintf("The entry %s does not match its digest.", key),
+			)
+		}
+	}
+	return violations
+}
+
+// Attribute Methods
+
+func (v *contentStore_) GetSize() uint {
+	var size uint
+	for _, keys := range v.versions_ {
+		size += uint(len(keys))
+	}
+	return size
+}
+
+// Resolving Methods
+
+func (v *contentStore_) RetrieveDocument(
+	citation com.CitationLike,
+) com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to retrieve a document",
+	)
+
+	var key, ok = v.key(citation)
+	if !ok || !uti.PathExists(v.filename(key)) {
+		return nil
+	}
+
+	// A retrieved document must match the citation that was used to find it,
+	// which may have digested one of its earlier formats.
+	var document = com.DocumentClass().DocumentFromSource(
+		uti.ReadFile(v.filename(key)),
+	)
+	var digest = v.ssm_.DigestBytes(document.AsCanonical())
+	var expected = hex.EncodeToString(citation.GetDigest().AsIntrinsic())
+	var content = document.GetContent()
+	if hex.EncodeToString(digest) != key ||
+		(expected != key && !sli.Contains(v.aliasKeys(document), expected)) ||
+		content.GetTag().AsSource() != citation.GetTag().AsSource() ||
+		content.GetVersion().AsSource() != citation.GetVersion().AsSource() {
+		var message = fmt.Sprintf(
+			"The stored document does not match its citation: %s",
+			citation.AsResource().AsSource(),
+		)
+		panic(message)
+	}
+	return document
+}
+
+func (v *contentStore_) RetrieveVersions(
+	tag doc.TagLike,
+) []com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to retrieve the versions of a document",
+	)
+
+	var versions []com.DocumentLike
+	for _, key := range v.versions_[tag.AsSource()] {
+		var source = uti.ReadFile(v.filename(key))
+		versions = append(versions, com.DocumentClass().DocumentFromSource(source))
+	}
+	return versions
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *contentStore_) aliasKeys(
+	document com.DocumentLike,
+) []string {
+	// Citations created using an earlier format digested the first canonical
+	// bytes or, before that, the formatted source.
+	var legacy = v.ssm_.DigestBytes(com.CanonicalClass().FormatBytes(
+		document.AsIntrinsic(),
+		com.CanonicalClass().LegacyFormat(),
+	))
+	var source = v.ssm_.DigestBytes([]byte(document.AsSource()))
+	return []string{hex.EncodeToString(legacy), hex.EncodeToString(source)}
+}
+
+func (v *contentStore_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"ContentStore: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *contentStore_) filename(
+	key string,
+) string {
+	return v.namespace_ + "/" + key + ".bali"
+}
+
+func (v *contentStore_) indexDocument(
+	key string,
+	document com.DocumentLike,
+) {
+	// Citations that digested an earlier format of the document are aliases
+	// for the digest that it is stored under.
+	var tag = document.GetContent().GetTag().AsSource()
+	v.versions_[tag] = append(v.versions_[tag], key)
+	for _, alias := range v.aliasKeys(document) {
+		if alias != key {
+			v.aliases_[alias] = key
+		}
+	}
+}
+
+func (v *contentStore_) key(
+	citation com.CitationLike,
+) (
+	key string,
+	ok bool,
+) {
+	// Citations using a different digest algorithm belong to another namespace.
+	var algorithm = doc.Quote(`"` + v.ssm_.GetDigestAlgorithm() + `"`)
+	if citation.GetAlgorithm().AsSource() != algorithm.AsSource() {
+		return
+	}
+	key = hex.EncodeToString(citation.GetDigest().AsIntrinsic())
+	if alias, found := v.aliases_[key]; found {
+		key = alias
+	}
+	ok = true
+	return
+}
+
+func (c *contentStoreClass_) parseCitation(
+	source string,
+) (
+	citation com.CitationLike,
+) {
+	// Anything that is not a valid citation is ignored.
+	defer func() {
+		if e := recover(); e != nil {
+			citation = nil
+		}
+	}()
+	if sts.HasPrefix(source, "<") {
+		citation = com.CitationClass().CitationFromResource(doc.Resource(source))
+		return
+	}
+	citation = com.CitationClass().CitationFromSource(source)
+	return
+}
+
+func (v *contentStore_) parseDocument(
+	source string,
+) (
+	document com.DocumentLike,
+) {
+	// A corrupt entry results in an undefined document.
+	defer func() {
+		if e := recover(); e != nil {
+			document = nil
+		}
+	}()
+	document = com.DocumentClass().DocumentFromSource(source)
+	return
+}
+
+func (v *contentStore_) readIndex() {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to index the store",
+	)
+
+	// Corrupt entries are left out of the index and reported by a scrub.
+	for _, filename := range uti.ReadDirectory(v.namespace_) {
+		var key, found = sts.CutSuffix(filename, ".bali")
+		if !found {
+			continue
+		}
+		var document = v.parseDocument(uti.ReadFile(v.filename(key)))
+		if uti.IsDefined(document) {
+			v.indexDocument(key, document)
+		}
+	}
+}
+
+func (v *contentStore_) unindexed() []string {
+	var indexed = make(map[string]bool)
+	for _, keys := range v.versions_ {
+		for _, key := range keys {
+			indexed[key] = true
+		}
+	}
+	var keys []string
+	for _, filename := range uti.ReadDirectory(v.namespace_) {
+		var key, found = sts.CutSuffix(filename, ".bali")
+		if found && !indexed[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Instance Structure
+
+type contentStore_ struct {
+	// Declare the instance attributes.
+	ssm_       Trusted
+	namespace_ string
+	versions_  map[string][]string
+	aliases_   map[string]string
+}
+
+// Class Structure
+
+type contentStoreClass_ struct {
+	// Declare the class constants.
+	citation_ *reg.Regexp
+	resource_ *reg.Regexp
+}
+
+// Class Reference
+
+func contentStoreClass() *contentStoreClass_ {
+	return contentStoreClassReference_
+}
+
+var contentStoreClassReference_ = &contentStoreClass_{
+	// Initialize the class constants.
+	citation_: reg.MustCompile(
+		`\[\s*\$tag:[^\[\]]*\]\(\s*\$type: /bali/types/notary/Citation/v3\s*\)`,
+	),
+	resource_: reg.MustCompile(`<nebula:/[^<>\s]+>`),
+}
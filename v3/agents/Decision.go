@@ -0,0 +1,329 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func DecisionClass() DecisionClassLike {
+	return decisionClass()
+}
+
+// Constructor Methods
+
+func (c *decisionClass_) Decision(
+	notary DigitalNotaryLike,
+	policy com.PolicyLike,
+	document com.DocumentLike,
+	certificates []com.DocumentLike,
+	moment doc.MomentLike,
+) DecisionLike {
+	if uti.IsUndefined(notary) {
+		panic("The \"notary\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(policy) {
+		panic("The \"policy\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(document) {
+		panic("The \"document\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(moment) {
+		panic("The \"moment\" attribute is required by this class.")
+	}
+	var instance = &decision_{
+		// Initialize the instance attributes.
+		notary_:       notary,
+		policy_:       policy,
+		document_:     document,
+		certificates_: certificates,
+		moment_:       moment,
+	}
+	instance.evaluatePolicy()
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *decision_) GetClass() DecisionClassLike {
+	return decisionClass()
+}
+
+func (v *decision_) IsAllowed() bool {
+	return len(v.reasons_) == 0
+}
+
+// Attribute Methods
+
+func (v *decision_) GetPolicy() com.PolicyLike {
+	return v.policy_
+}
+
+func (v *decision_) GetDocument() com.DocumentLike {
+	return v.document_
+}
+
+func (v *decision_) GetMoment() doc.MomentLike {
+	return v.moment_
+}
+
+func (v *decision_) GetReasons() []string {
+	return v.reasons_
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *decision_) addReason(
+	format string,
+	arguments ...any,
+) {
+	var reason = fmt.Sprintf(format, arguments...)
+	v.reasons_ = append(v.reasons_, reason)
+}
+
+func (v *decision_) evaluatePolicy() {
+	// Find the rule that applies to the document.
+	var type_ = v.document_.GetContent().GetType()
+	var found bool
+	for _, candidate := range v.policy_.GetTypes() {
+		if candidate.AsSource() == type_.AsSource() {
+			found = true
+			break
+		}
+	}
+	if !found {
+		v.addReason(
+			"The policy has no rule for documents of type %s.",
+			type_.AsSource(),
+		)
+		return
+	}
+
+	// Find the certificate that notarized the document.
+	if !v.document_.IsNotarized() {
+		v.addReason("The document has not been notarized.")
+		return
+	}
+	var citation = v.document_.GetNotaryCitation()
+	if uti.IsUndefined(citation) {
+		v.addReason("The document does not cite the certificate that notarized it.")
+		return
+	}
+	var certificate = v.findCertificate(citation)
+	if uti.IsUndefined(certificate) {
+		v.addReason(
+			"The certificate %s that notarized the document was not provided.",
+			citation.AsResource().AsSource(),
+		)
+		return
+	}
+	var reason = v.verifyChain(certificate)
+	if len(reason) > 0 {
+		v.reasons_ = append(v.reasons_, reason)
+		return
+	}
+	reason = v.verifySeal(v.document_, certificate, "the document")
+	if len(reason) > 0 {
+		v.reasons_ = append(v.reasons_, reason)
+		return
+	}
+
+	// Apply each restriction in the rule.
+	var content = certificate.GetContent()
+	var notaries = v.policy_.GetNotaries(type_)
+	if len(notaries) > 0 && !v.isAccepted(content.GetTag(), notaries) {
+		v.addReason(
+			"The certificate %s is not one of the notaries accepted by the policy.",
+			content.GetTag().AsSource(),
+		)
+	}
+	var maximumAge = v.policy_.GetOptionalMaximumAge(type_)
+	if uti.IsDefined(maximumAge) {
+		var component = v.document_.GetSubcomponent(
+			doc.Symbol("$notaries"),
+			-1, // The last notary seal.
+		)
+		var notary = com.NotaryClass().NotaryFromSource(
+			doc.FormatComponent(component),
+		)
+		var timestamp = notary.GetTimestamp()
+		var earliest = doc.MomentClass().Earlier(v.moment_, maximumAge)
+		switch {
+		case timestamp.AsIntrinsic() > v.moment_.AsIntrinsic():
+			v.addReason(
+				"The seal timestamp %s is later than the moment of the decision %s.",
+				timestamp.AsSource(),
+				v.moment_.AsSource(),
+			)
+		case timestamp.AsIntrinsic() < earliest.AsIntrinsic():
+			v.addReason(
+				"The seal timestamp %s is older than the maximum age %s.",
+				timestamp.AsSource(),
+				maximumAge.AsSource(),
+			)
+		}
+	}
+	var minimumVersion = v.policy_.GetOptionalMinimumVersion(type_)
+	if uti.IsDefined(minimumVersion) {
+		var version = content.GetVersion()
+		if v.isEarlier(version, minimumVersion) {
+			v.addReason(
+				"The certificate version %s is earlier than the minimum version %s.",
+				version.AsSource(),
+				minimumVersion.AsSource(),
+			)
+		}
+	}
+}
+
+func (v *decision_) findCertificate(
+	citation com.CitationLike,
+) com.DocumentLike {
+	for _, certificate := range v.certificates_ {
+		if v.notary_.CitationMatches(citation, certificate) {
+			return certificate
+		}
+	}
+	return nil
+}
+
+func (v *decision_) isAccepted(
+	tag doc.TagLike,
+	notaries []doc.TagLike,
+) bool {
+	for _, notary := range notaries {
+		if notary.AsSource() == tag.AsSource() {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *decision_) isEarlier(
+	version doc.VersionLike,
+	minimum doc.VersionLike,
+) bool {
+	// Versions are compared ordinal by ordinal (e.g. v2.9 < v3 < v3.1).
+	var first = version.AsIntrinsic()
+	var second = minimum.AsIntrinsic()
+	for index := 0; index < len(first) && index < len(second); index++ {
+		if first[index] != second[index] {
+			return first[index] < second[index]
+		}
+	}
+	return len(first) < len(second)
+}
+
+func (v *decision_) verifyChain(
+	certificate com.DocumentLike,
+) string {
+	// Each version of the certificate must be certified by its previous
+	// version, back to a first version which is sealed using itself.  Since
+	// every version must have been provided, the tag of the certificate is
+	// bound to the key of a provided first version.
+	var current = certificate
+	for {
+		var content = current.GetContent()
+		var previous = content.GetOptionalPrevious()
+		if uti.IsUndefined(previous) {
+			if uti.IsDefined(current.GetNotaryCitation()) {
+				return "The first version of the certificate is not self-signed."
+			}
+			return v.verifySeal(current, current, "the first version of the certificate")
+		}
+		var prior = v.findCertificate(
+			com.CitationClass().CitationFromResource(previous),
+		)
+		if uti.IsUndefined(prior) {
+			return fmt.Sprintf(
+				"The previous version of certificate version %s was not provided.",
+				content.GetVersion().AsSource(),
+			)
+		}
+		var notary = current.GetNotaryCitation()
+		if uti.IsUndefined(notary) || !v.notary_.CitationMatches(notary, prior) {
+			return fmt.Sprintf(
+				"Version %s of the certificate is not certified by its previous version.",
+				content.GetVersion().AsSource(),
+			)
+		}
+		var subject = "version " + content.GetVersion().AsSource() + " of the certificate"
+		var reason = v.verifySeal(current, prior, subject)
+		if len(reason) > 0 {
+			return reason
+		}
+		current = prior
+	}
+}
+
+func (v *decision_) verifySeal(
+	document com.DocumentLike,
+	certificate com.DocumentLike,
+	subject string,
+) (
+	reason string,
+) {
+	// A seal that cannot be verified is a reason to deny the document.
+	defer func() {
+		if e := recover(); e != nil {
+			reason = fmt.Sprintf("The seal on %s could not be verified: %v", subject, e)
+		}
+	}()
+	if !v.notary_.SealMatches(document, certificate) {
+		reason = fmt.Sprintf("The seal on %s does not match its certificate.", subject)
+	}
+	return
+}
+
+// Instance Structure
+
+type decision_ struct {
+	// Declare the instance attributes.
+	notary_       DigitalNotaryLike
+	policy_       com.PolicyLike
+	document_     com.DocumentLike
+	certificates_ []com.DocumentLike
+	moment_       doc.MomentLike
+	reasons_      []string
+}
+
+// Class Structure
+
+type decisionClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func decisionClass() *decisionClass_ {
+	return decisionClassReference_
+}
+
+var decisionClassReference_ = &decisionClass_{
+	// Initialize the class constants.
+}
@@ -14,10 +14,18 @@ package agents
 
 import (
 	byt "bytes"
+	aes "crypto/aes"
+	cip "crypto/cipher"
+	ecd "crypto/ecdh"
+	hkd "crypto/hkdf"
+	ran "crypto/rand"
+	dig "crypto/sha512"
 	fmt "fmt"
 	doc "github.com/bali-nebula/go-bali-documents/v3"
 	com "github.com/bali-nebula/go-digital-notary/v3/components"
 	uti "github.com/craterdog/go-essential-utilities/v8"
+	stc "strconv"
+	sts "strings"
 )
 
 // CLASS INTERFACE
@@ -72,30 +80,30 @@ func (c *digitalNotaryClass_) DigitalNotaryWithCertificate(
 		panic("a notarized \"certificate\" attribute is required by this class.")
 	}
 
-	// Validate the seal on the certificate document.
-	var seal = certificate.RemoveNotarySeal()
-	var source = certificate.AsSource()
-	var sourceBytes = []byte(source)
-	certificate.SetNotarySeal(seal)
-	var keyBytes = hsm.GetPublicKey()
-	var signatureBytes = seal.GetSignature().AsIntrinsic()
-	if !hsm.IsValid(keyBytes, sourceBytes, signatureBytes) {
-		var message = fmt.Sprintf(
-			"The \"certificate\" document is invalid: %s\n",
-			certificate.AsSource(),
-		)
-		panic(message)
-	}
+	// Without a repository the previous versions of the certificate cannot be
+	// retrieved so only a self-signed certificate can be validated.
+	return c.restoreNotary(ssm, hsm, certificate, nil)
+}
 
-	// Create the new digital notary.
-	var instance = &digitalNotary_{
-		// Initialize the instance attributes.
-		ssm_:         ssm,
-		hsm_:         hsm,
-		certificate_: certificate,
+func (c *digitalNotaryClass_) DigitalNotaryWithRepository(
+	ssm Trusted,
+	hsm Hardened,
+	certificate com.DocumentLike,
+	repository Resolving,
+) DigitalNotaryLike {
+	if uti.IsUndefined(ssm) {
+		panic("The \"ssm\" attribute is required by this class.")
 	}
-
-	return instance
+	if uti.IsUndefined(hsm) {
+		panic("The \"hsm\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(certificate) || !certificate.IsNotarized() {
+		panic("a notarized \"certificate\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(repository) {
+		panic("The \"repository\" attribute is required by this class.")
+	}
+	return c.restoreNotary(ssm, hsm, certificate, repository)
 }
 
 // Constant Methods
@@ -120,9 +128,7 @@ func (v *digitalNotary_) CiteDocument(
 
 	// Create a citation to the document.
 	var algorithm = doc.Quote(`"` + v.ssm_.GetDigestAlgorithm() + `"`)
-	var source = document.AsSource()
-	var bytes = []byte(source)
-	var digest = doc.Binary(v.ssm_.DigestBytes(bytes))
+	var digest = doc.Binary(v.ssm_.DigestBytes(document.AsCanonical()))
 	var content = document.GetContent()
 	var tag = content.GetTag()
 	var version = content.GetVersion()
@@ -144,20 +150,30 @@ func (v *digitalNotary_) CitationMatches(
 		"An error occurred while attempting to verify a document citation",
 	)
 
-	// Compare the citation digest with a digest of the document.
-	var citationAlgorithm = citation.GetAlgorithm()
-	var citationDigest = citation.GetDigest()
-	var ssmAlgorithm = doc.Quote(`"` + v.ssm_.GetDigestAlgorithm() + `"`)
-	var source = document.AsSource()
-	var bytes = []byte(source)
-	var documentDigest = doc.Binary(v.ssm_.DigestBytes(bytes))
-	if citationAlgorithm.AsSource() != ssmAlgorithm.AsSource() {
+	// Find the SSM for the citation digest algorithm.
+	var ssm = v.ssmFor(string(citation.GetAlgorithm().AsIntrinsic()))
+	if uti.IsUndefined(ssm) {
 		return false
 	}
-	if !byt.Equal(citationDigest.AsIntrinsic(), documentDigest.AsIntrinsic()) {
-		return false
+
+	// Compare the citation digest with a digest of the canonical document.
+	var citationDigest = citation.GetDigest().AsIntrinsic()
+	var canonicalDigest = ssm.DigestBytes(document.AsCanonical())
+	if byt.Equal(citationDigest, canonicalDigest) {
+		return true
 	}
-	return true
+
+	// Citations created using an earlier format digested the first canonical
+	// bytes or, before that, the formatted source.
+	var legacyDigest = ssm.DigestBytes(com.CanonicalClass().FormatBytes(
+		document.AsIntrinsic(),
+		com.CanonicalClass().LegacyFormat(),
+	))
+	if byt.Equal(citationDigest, legacyDigest) {
+		return true
+	}
+	var sourceDigest = ssm.DigestBytes([]byte(document.AsSource()))
+	return byt.Equal(citationDigest, sourceDigest)
 }
 
 func (v *digitalNotary_) ForgetKey() {
@@ -166,6 +182,9 @@ func (v *digitalNotary_) ForgetKey() {
 		"An error occurred while attempting to forget the private key",
 	)
 
+	// The event is recorded first so that it can still be notarized.
+	v.recordEvent("ForgetKey", v.certificate_)
+
 	// Erase the stored keys and certificate citation.
 	v.certificate_ = nil
 	v.hsm_.EraseKeys()
@@ -201,11 +220,14 @@ func (v *digitalNotary_) GenerateKey(
 		version,
 		previous,
 	)
+	v.addAgreementKey(identity)
 	var certificate = com.DocumentClass().Document(identity)
 
 	// Notarize the document using its own key.
 	v.notarizeDocument(certificate)
 	v.certificate_ = certificate
+	v.logDocument(certificate)
+	v.recordEvent("GenerateKey", certificate)
 	return certificate
 }
 
@@ -220,34 +242,168 @@ func (v *digitalNotary_) RefreshKey() com.DocumentLike {
 		panic("The digital notary has not yet been initialized.")
 	}
 
-	// Generate a new key pair.
+	// Generate a new key pair and certify it using the previous key.
 	var bytes = v.hsm_.RotateKeys() // Returns the new public key.
-	var key = doc.Binary(bytes)
-	var algorithm = doc.Quote(`"` + v.hsm_.GetSignatureAlgorithm() + `"`)
+	var certificate = v.certifyKey(bytes)
+	v.recordEvent("RefreshKey", certificate)
+	return certificate
+}
 
-	// Create the new certificate document.
-	var content = v.certificate_.GetContent()
-	var identity = com.IdentityClass().IdentityFromSource(
-		content.AsSource(),
+func (v *digitalNotary_) Recover() com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to recover an interrupted key rotation",
 	)
-	var attributes = identity.GetAttributes()
-	var tag = content.GetTag()
-	var version = doc.VersionClass().GetNextVersion(content.GetVersion(), 0)
-	var previous = v.CiteDocument(v.certificate_).AsResource()
-	var certificate = com.IdentityClass().Identity(
-		algorithm,
-		key,
-		attributes,
-		tag,
-		version,
-		previous,
+
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+
+	// A rotation that was interrupted before the new certificate was recorded
+	// is rolled back since the new certificate was never created.
+	var certificate = v.pendingCertificate()
+	if uti.IsUndefined(certificate) {
+		if uti.IsDefined(v.hsm_.GetPreviousKey()) {
+			v.hsm_.AbortRotation()
+			v.recordEvent("Recover", v.certificate_)
+		}
+		return v.certificate_
+	}
+
+	// Otherwise the recorded certificate is completed, signing it using the
+	// previous key unless it was already signed before the interruption.
+	var signature = v.hsm_.GetRotationSignature()
+	if uti.IsUndefined(signature) {
+		signature = v.hsm_.SignWithPreviousKey(certificate.AsCanonical())
+	}
+	v.addSeal(certificate, signature)
+	if !v.SealMatches(certificate, v.certificate_) {
+		panic("The recorded certificate is not certified by the current certificate.")
+	}
+	v.certificate_ = certificate
+	v.logDocument(certificate)
+	v.recordEvent("Recover", certificate)
+	return certificate
+}
+
+func (v *digitalNotary_) BackupKey(
+	threshold uint,
+	count uint,
+) []com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to back up the private key",
 	)
-	var document = com.DocumentClass().Document(certificate)
 
-	// Notarize the document using the previous key.
-	v.notarizeDocument(document)
-	v.certificate_ = document
-	return document
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+
+	// Split the private key into shares.  Only a security module that
+	// implements the Exportable interface can be backed up.  No module in this
+	// package does so, since a hardware security module never releases its
+	// private key, so this is currently limited to software test modules.
+	var exportable, ok = v.hsm_.(Exportable)
+	if !ok {
+		panic("The HSM does not support the backing up of its private key.")
+	}
+	var secret = exportable.ExportKey()
+	var shares = ShamirClass().SplitSecret(secret, threshold, count)
+
+	// Create a notarized document for each share.
+	var citation = v.CiteDocument(v.certificate_).AsResource()
+	var documents = make([]com.DocumentLike, len(shares))
+	for index, share := range shares {
+		var entity = doc.ParseComponent(`[
+    $certificate: ` + citation.AsSource() + `
+    $threshold: ` + stc.Itoa(int(threshold)) + `
+    $share: ` + doc.Binary(share).AsSource() + `
+]`)
+		var type_ = doc.Name("/bali/types/notary/KeyShare/v3")
+		var tag = doc.Tag()
+		var version = doc.Version()
+		var permissions = doc.Name("/bali/permissions/Private/v3")
+		var previous doc.ResourceLike
+		var content = com.ContentClass().Content(
+			entity,
+			type_,
+			tag,
+			version,
+			permissions,
+			previous,
+		)
+		var document = com.DocumentClass().Document(content)
+		v.notarizeDocument(document)
+		documents[index] = document
+	}
+	v.recordEvent("BackupKey", v.certificate_)
+	return documents
+}
+
+func (v *digitalNotary_) RestoreKey(
+	shares []com.DocumentLike,
+	certificate com.DocumentLike,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to restore the private key",
+	)
+
+	// Make sure the digital notary has not been initialized.
+	if uti.IsDefined(v.certificate_) {
+		panic("The digital notary has already been initialized.")
+	}
+	var exportable, ok = v.hsm_.(Exportable)
+	if !ok {
+		panic("The HSM does not support the restoring of its private key.")
+	}
+
+	// Extract the shares for the certificate.
+	var secrets = make([][]byte, len(shares))
+	for index, share := range shares {
+		var content = com.ContentClass().ContentFromSource(
+			share.GetContent().AsSource(),
+		)
+		if content.GetType().AsSource() != "/bali/types/notary/KeyShare/v3" {
+			panic("A document that is not a key share was passed as a share.")
+		}
+		var entity = content.AsIntrinsic()
+		var component = entity.GetSubcomponent(doc.Symbol("$certificate"))
+		var resource = doc.Resource(doc.FormatComponent(component))
+		var citation = com.CitationClass().CitationFromResource(resource)
+		if !v.CitationMatches(citation, certificate) || !v.SealMatches(share, certificate) {
+			panic("A key share does not belong to the specified certificate.")
+		}
+		component = entity.GetSubcomponent(doc.Symbol("$threshold"))
+		var threshold, err = stc.Atoi(doc.FormatComponent(component))
+		if err != nil {
+			panic(err)
+		}
+		if len(shares) < threshold {
+			var message = fmt.Sprintf(
+				"At least %d key shares are required to restore the key.",
+				threshold,
+			)
+			panic(message)
+		}
+		component = entity.GetSubcomponent(doc.Symbol("$share"))
+		secrets[index] = doc.Binary(doc.FormatComponent(component)).AsIntrinsic()
+	}
+
+	// Restore the private key and verify it against the certificate.
+	var secret = ShamirClass().CombineShares(secrets)
+	var publicKey = exportable.ImportKey(secret)
+	var identity = com.IdentityClass().IdentityFromSource(
+		certificate.GetContent().AsSource(),
+	)
+	if !byt.Equal(publicKey, identity.GetKey().AsIntrinsic()) {
+		v.hsm_.EraseKeys()
+		panic("The restored key does not match the certificate key.")
+	}
+	v.certificate_ = certificate
+	v.recordEvent("RestoreKey", certificate)
 }
 
 func (v *digitalNotary_) GenerateCredential(
@@ -281,6 +437,7 @@ func (v *digitalNotary_) GenerateCredential(
 
 	// Notarize the credential document.
 	v.notarizeDocument(document)
+	v.recordEvent("GenerateCredential", document)
 
 	return document
 }
@@ -294,31 +451,25 @@ func (v *digitalNotary_) RefreshCredential(
 		"An error occurred while attempting to refresh a security credential",
 	)
 
-	// Make sure the digital notary has been initialized.
-	if uti.IsUndefined(v.certificate_) {
-		panic("The digital notary has not yet been initialized.")
-	}
+	// Create and notarize the next version of the credential document.
+	var credential = v.notarizeNextVersion(document, context, LastLevel)
+	v.recordEvent("RefreshCredential", credential)
+	return credential
+}
 
-	// Create the next version of the credential document.
-	var previous = v.CiteDocument(document).AsResource()
-	var content = document.GetContent()
-	var type_ = content.GetType()
-	var tag = content.GetTag()
-	var version = doc.VersionClass().GetNextVersion(content.GetVersion(), 0)
-	var permissions = content.GetPermissions()
-	var credential = com.ContentClass().Content(
-		context,
-		type_,
-		tag,
-		version,
-		permissions,
-		previous,
+func (v *digitalNotary_) NotarizeNextVersion(
+	previous com.DocumentLike,
+	entity any,
+	level int,
+) com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to notarize the next version of a document",
 	)
-	document = com.DocumentClass().Document(credential)
-
-	// Notarize the credential document.
-	v.notarizeDocument(document)
 
+	// Create and notarize the next version of the document.
+	var document = v.notarizeNextVersion(previous, entity, level)
+	v.recordEvent("NotarizeNextVersion", document)
 	return document
 }
 
@@ -337,6 +488,8 @@ func (v *digitalNotary_) NotarizeDocument(
 
 	// Notarize the document.
 	v.notarizeDocument(document)
+	v.logDocument(document)
+	v.recordEvent("NotarizeDocument", document)
 }
 
 func (v *digitalNotary_) SealMatches(
@@ -348,81 +501,1341 @@ func (v *digitalNotary_) SealMatches(
 		"An error occurred while attempting to match a document seal",
 	)
 
-	// Compare the signature algorithms for the public certificate and SSM.
+	// Find the HSM for the signature algorithm of the public certificate.
 	var identity = com.IdentityClass().IdentityFromSource(
 		certificate.GetContent().AsSource(),
 	)
 	var certificateAlgorithm = string(identity.GetAlgorithm().AsIntrinsic())
-	var hsmAlgorithm = v.hsm_.GetSignatureAlgorithm()
-	if certificateAlgorithm != hsmAlgorithm {
+	var hsm = v.hsmFor(certificateAlgorithm)
+	if uti.IsUndefined(hsm) {
 		var message = fmt.Sprintf(
-			"The certificate algorithm %q is incompatible with the SSM algorithm %q.",
+			"The certificate algorithm %q is not supported by any HSM.",
 			certificateAlgorithm,
-			hsmAlgorithm,
 		)
 		panic(message)
 	}
 
 	// Validate the seal on the notarized document.
 	var publicKey = identity.GetKey()
-	var seal = document.RemoveNotarySeal()
-	var source = document.AsSource()
-	var sourceBytes = []byte(source)
-	document.SetNotarySeal(seal)
+	var seal, sourceBytes = digitalNotaryClass().signedBytes(document)
+	if string(seal.GetAlgorithm().AsIntrinsic()) != certificateAlgorithm ||
+		!v.payloadMatches(document, seal) {
+		return false
+	}
 	var keyBytes = publicKey.AsIntrinsic()
 	var signatureBytes = seal.GetSignature().AsIntrinsic()
-	return v.hsm_.IsValid(keyBytes, sourceBytes, signatureBytes)
+	return hsm.IsValid(keyBytes, sourceBytes, signatureBytes)
 }
 
-// Attribute Methods
+func (v *digitalNotary_) CertificateMatches(
+	certificate com.DocumentLike,
+	root com.DocumentLike,
+	repository Resolving,
+) bool {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to match a certificate chain",
+	)
 
-// PROTECTED INTERFACE
+	if uti.IsUndefined(certificate) {
+		panic("The \"certificate\" attribute is required by this method.")
+	}
+	if uti.IsUndefined(root) {
+		panic("The \"root\" attribute is required by this method.")
+	}
+	if uti.IsUndefined(repository) {
+		panic("The \"repository\" attribute is required by this method.")
+	}
 
-// Private Methods
+	// Both certificates must be valid versions of the same first version, which
+	// binds the tag of the certificate to the key of the trusted root.
+	var first = v.firstVersion(certificate, repository)
+	var trusted = v.firstVersion(root, repository)
+	return uti.IsDefined(first) && uti.IsDefined(trusted) &&
+		byt.Equal(first.AsCanonical(), trusted.AsCanonical())
+}
 
-func (v *digitalNotary_) errorCheck(
-	message string,
-) {
-	if e := recover(); e != nil {
-		message = fmt.Sprintf(
-			"DigitalNotary: %s:\n    %v",
-			message,
-			e,
+func (v *digitalNotary_) EncryptDocument(
+	document com.DocumentLike,
+	recipients []com.DocumentLike,
+) com.EnvelopeLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to encrypt a document",
+	)
+
+	// Only sealed documents may be encrypted.
+	if uti.IsUndefined(document) || !document.IsNotarized() {
+		panic("Only a notarized document may be encrypted.")
+	}
+	if len(recipients) == 0 {
+		panic("At least one recipient is required.")
+	}
+
+	// Encrypt the notarized document using a new random content key.
+	var ephemeral, err = ecd.X25519().GenerateKey(ran.Reader)
+	if err != nil {
+		panic(err)
+	}
+	var ephemeralKey = ephemeral.PublicKey().Bytes()
+	var contentKey = make([]byte, 32)
+	ran.Read(contentKey)
+	var ciphertext = v.sealBytes(
+		contentKey,
+		[]byte(document.AsSource()),
+		ephemeralKey,
+	)
+	var algorithm = doc.Quote(`"` + digitalNotaryClass().encryption_ + `"`)
+	var envelope = com.EnvelopeClass().Envelope(
+		algorithm,
+		doc.Binary(ephemeralKey),
+		doc.Binary(ciphertext),
+	)
+
+	// Wrap the content key for each recipient.
+	for _, certificate := range recipients {
+		var recipientKey = v.agreementKey(certificate)
+		var publicKey, err = ecd.X25519().NewPublicKey(recipientKey)
+		if err != nil {
+			panic(err)
+		}
+		secret, err := ephemeral.ECDH(publicKey)
+		if err != nil {
+			panic(err)
+		}
+		var recipient = v.CiteDocument(certificate).AsResource()
+		var wrappingKey = v.deriveKey(secret, ephemeralKey, recipientKey)
+		var wrappedKey = v.sealBytes(
+			wrappingKey,
+			contentKey,
+			[]byte(recipient.AsSource()),
+		)
+		envelope.AddRecipient(recipient, doc.Binary(wrappedKey))
+	}
+	v.recordEvent("EncryptDocument", document)
+	return envelope
+}
+
+func (v *digitalNotary_) DecryptEnvelope(
+	envelope com.EnvelopeLike,
+	certificate com.DocumentLike,
+) com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to decrypt an envelope",
+	)
+
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+	var hsm, ok = v.hsm_.(Agreeing)
+	if !ok {
+		panic("The HSM does not support key agreement.")
+	}
+	var algorithm = string(envelope.GetAlgorithm().AsIntrinsic())
+	if algorithm != digitalNotaryClass().encryption_ {
+		var message = fmt.Sprintf(
+			"The envelope algorithm %q is not supported.",
+			algorithm,
 		)
 		panic(message)
 	}
+
+	// Unwrap the content key using the agreement key in the HSM.
+	var recipient = v.CiteDocument(v.certificate_).AsResource()
+	var wrappedKey = envelope.GetWrappedKey(recipient)
+	if uti.IsUndefined(wrappedKey) {
+		panic("The envelope is not addressed to this digital notary.")
+	}
+	var ephemeralKey = envelope.GetEphemeralKey().AsIntrinsic()
+	var secret = hsm.AgreeSecret(ephemeralKey)
+	var wrappingKey = v.deriveKey(secret, ephemeralKey, hsm.GetAgreementKey())
+	var contentKey = v.openBytes(
+		wrappingKey,
+		wrappedKey.AsIntrinsic(),
+		[]byte(recipient.AsSource()),
+	)
+
+	// Decrypt the notarized document and verify its seal.
+	var plaintext = v.openBytes(
+		contentKey,
+		envelope.GetCiphertext().AsIntrinsic(),
+		ephemeralKey,
+	)
+	var document = com.DocumentClass().DocumentFromSource(string(plaintext))
+	if !v.SealMatches(document, certificate) {
+		panic("The seal on the decrypted document is invalid.")
+	}
+	v.recordEvent("DecryptEnvelope", document)
+	return document
 }
 
-func (v *digitalNotary_) notarizeDocument(
+func (v *digitalNotary_) ProofMatches(
 	document com.DocumentLike,
-) {
-	// Check for new certificate document.
-	var owner doc.TagLike
-	var citation com.CitationLike
-	if uti.IsDefined(v.certificate_) {
-		owner = v.certificate_.GetContent().GetTag()
-		citation = v.CiteDocument(v.certificate_)
-	} else {
-		owner = doc.Tag() // Generate a new random tag.
+	treeHead com.DocumentLike,
+) bool {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to match a transparency log proof",
+	)
+
+	// The citation that was logged does not include the proof.
+	if !document.IsNotarized() {
+		return false
+	}
+	var proof = document.RemoveNotaryProof()
+	if uti.IsUndefined(proof) {
+		return false
 	}
+	var citation = v.CiteDocument(document)
+	document.SetNotaryProof(proof)
 
-	// Add the notary attribute to the document.
-	var notary = com.NotaryClass().Notary(
-		owner,
-		citation,
+	// The proof must be for the specified tree head.
+	var resource = proof.GetTreeHead()
+	if !v.CitationMatches(com.CitationClass().CitationFromResource(resource), treeHead) {
+		return false
+	}
+	var head = com.TreeHeadClass().TreeHeadFromSource(
+		treeHead.GetContent().AsSource(),
 	)
-	document.AddNotary(notary)
+	if head.GetSize() != proof.GetSize() {
+		return false
+	}
+	var path [][]byte
+	for _, hash := range proof.GetPath() {
+		path = append(path, hash.AsIntrinsic())
+	}
+	var tree = MerkleTreeClass().MerkleTree(v.ssm_)
+	return tree.VerifyInclusion(
+		[]byte(citation.AsResource().AsSource()),
+		proof.GetIndex(),
+		proof.GetSize(),
+		path,
+		head.GetRoot().AsIntrinsic(),
+	)
+}
 
-	// Digitally sign the document.
-	var algorithm = doc.Quote(`"` + v.hsm_.GetSignatureAlgorithm() + `"`)
-	var source = document.AsSource()
-	var bytes = v.hsm_.SignBytes([]byte(source))
-	var signature = doc.Binary(bytes)
-	var seal = com.SealClass().Seal(
-		algorithm,
-		signature,
+func (v *digitalNotary_) ExportJWS(
+	document com.DocumentLike,
+) string {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to export a document as a JWS",
 	)
-	document.SetNotarySeal(seal)
+
+	var format = com.CanonicalClass().JWSFormat()
+	var payload = v.notarizePayload(document, format)
+	v.recordEvent("ExportJWS", payload)
+	return payload.AsJWS()
+}
+
+func (v *digitalNotary_) ExportCOSE(
+	document com.DocumentLike,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to export a document as a COSE_Sign1",
+	)
+
+	var format = com.CanonicalClass().COSEFormat()
+	var payload = v.notarizePayload(document, format)
+	v.recordEvent("ExportCOSE", payload)
+	return payload.AsCOSE()
+}
+
+func (v *digitalNotary_) ExportCredential(
+	credential com.DocumentLike,
+) string {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to export a verifiable credential",
+	)
+
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+	var exported = verifiableCredentialClass().issueCredential(
+		credential,
+		v.CiteDocument(credential),
+		v.certificate_,
+		v.SealMatches,
+		v.hsm_.SignBytes,
+	)
+	v.recordEvent("ExportCredential", credential)
+	return exported
+}
+
+func (v *digitalNotary_) ExportPGPKey(
+	version uint,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to export an OpenPGP public key",
+	)
+
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+	var userID = "Digital Notary " + v.CiteDocument(v.certificate_).AsResource().AsSource()
+	var key = openPGPClass().exportKey(v.certificate_, v.hsm_, userID, version)
+	v.recordEvent("ExportPGPKey", v.certificate_)
+	return key
+}
+
+func (v *digitalNotary_) ExportPGPSignature(
+	bytes []byte,
+	version uint,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to export an OpenPGP signature",
+	)
+
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+	var signature = openPGPClass().exportSignature(v.certificate_, v.hsm_, bytes, version)
+	v.recordEvent("ExportPGPSignature", nil)
+	return signature
+}
+
+func (v *digitalNotary_) AssembleBundle(
+	document com.DocumentLike,
+	repository Resolving,
+) com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to assemble an evidence bundle",
+	)
+
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+	if uti.IsUndefined(document) || !document.IsNotarized() {
+		panic("Only a notarized document may be bundled.")
+	}
+	var bundle = com.BundleClass().Bundle(document, doc.Tag(), doc.Version(), nil)
+
+	// Include the tree head that is cited by the inclusion proof for the
+	// document as its timestamp.
+	var citations = []com.CitationLike{
+		document.GetNotaryCitation(),
+		v.CiteDocument(v.certificate_), // The bundle itself is notarized.
+	}
+	var proof = document.RemoveNotaryProof()
+	if uti.IsDefined(proof) {
+		document.SetNotaryProof(proof)
+		var citation = com.CitationClass().CitationFromResource(proof.GetTreeHead())
+		var treeHead = repository.RetrieveDocument(citation)
+		if uti.IsUndefined(treeHead) || !v.CitationMatches(citation, treeHead) {
+			panic("The tree head cited by the inclusion proof could not be retrieved.")
+		}
+		bundle.AddTimestamp(treeHead)
+		citations = append(citations, treeHead.GetNotaryCitation())
+	}
+
+	// Include every version of each certificate along with a citation to its
+	// latest version.
+	var tags = make(map[string]bool)
+	for _, citation := range citations {
+		if uti.IsUndefined(citation) || tags[citation.GetTag().AsSource()] {
+			continue // The document is a self-signed certificate.
+		}
+		tags[citation.GetTag().AsSource()] = true
+		var chain = v.certificateChain(citation, repository)
+		for _, certificate := range chain {
+			bundle.AddCertificate(certificate)
+		}
+		bundle.AddStatus(v.CiteDocument(chain[len(chain)-1]))
+	}
+
+	// Notarize the bundle.
+	var result = com.DocumentClass().Document(bundle)
+	v.notarizeDocument(result)
+	v.logDocument(result)
+	v.recordEvent("AssembleBundle", result)
+	return result
+}
+
+func (v *digitalNotary_) VerifyBundle(
+	bundle com.DocumentLike,
+	certificates []com.DocumentLike,
+) []string {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to verify an evidence bundle",
+	)
+
+	if uti.IsUndefined(bundle) {
+		panic("The \"bundle\" attribute is required by this method.")
+	}
+	if len(certificates) == 0 {
+		panic("The \"certificates\" attribute is required by this method.")
+	}
+	var content = bundle.GetContent()
+	if content.GetType().AsSource() != "/bali/types/notary/Bundle/v3" {
+		panic("The document is not an evidence bundle.")
+	}
+	var evidence = com.BundleClass().BundleFromSource(content.AsSource())
+
+	// Each chain of certificate versions must be intact and end with the
+	// latest version at the time the bundle was assembled.
+	var violations []string
+	var tags []string
+	var chains = make(map[string][]com.DocumentLike)
+	for _, certificate := range evidence.GetCertificates() {
+		var tag = certificate.GetContent().GetTag().AsSource()
+		if _, ok := chains[tag]; !ok {
+			tags = append(tags, tag)
+		}
+		chains[tag] = append(chains[tag], certificate)
+	}
+	for _, tag := range tags {
+		violations = append(violations, v.checkChain(chains[tag], evidence.GetStatus())...)
+		if !v.chainTrusted(chains[tag], certificates) {
+			violations = append(violations, fmt.Sprintf(
+				"Certificate %s is not trusted.",
+				tag,
+			))
+		}
+	}
+
+	// The bundle, its timestamps and the document must each be sealed using a
+	// certificate that had not yet been superseded.
+	violations = append(violations, v.checkSeal("bundle", bundle, chains)...)
+	var treeHeads = evidence.GetTimestamps()
+	for index, treeHead := range treeHeads {
+		var name = "timestamp " + stc.Itoa(index+1)
+		violations = append(violations, v.checkSeal(name, treeHead, chains)...)
+	}
+	var document = evidence.GetDocument()
+	violations = append(violations, v.checkSeal("document", document, chains)...)
+
+	// The inclusion proof for the document must match its timestamp.
+	var proof = document.RemoveNotaryProof()
+	if uti.IsDefined(proof) {
+		document.SetNotaryProof(proof)
+		var citation = com.CitationClass().CitationFromResource(proof.GetTreeHead())
+		var found bool
+		for _, treeHead := range treeHeads {
+			if !v.CitationMatches(citation, treeHead) {
+				continue
+			}
+			found = true
+			if !v.ProofMatches(document, treeHead) {
+				violations = append(violations, "The inclusion proof for the document is invalid.")
+			}
+			var head = com.TreeHeadClass().TreeHeadFromSource(
+				treeHead.GetContent().AsSource(),
+			)
+			if head.GetTimestamp().AsIntrinsic() < v.notaryTimestamp(document) {
+				violations = append(violations, "The timestamp for the document is earlier than its seal.")
+			}
+		}
+		if !found {
+			violations = append(violations, "The timestamp for the document is missing.")
+		}
+	}
+	return violations
+}
+
+func (v *digitalNotary_) ResealDocument(
+	document com.DocumentLike,
+	repository Resolving,
+	trusted []com.DocumentLike,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to reseal a document",
+	)
+
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+	if uti.IsUndefined(document) || !document.IsNotarized() {
+		panic("Only a notarized document may be resealed.")
+	}
+
+	// The existing protections must still be valid when they are renewed.
+	var violations = v.VerifyArchive(document, repository, trusted)
+	if len(violations) > 0 {
+		var message = fmt.Sprintf(
+			"The existing seals on the document are invalid:\n        %s",
+			sts.Join(violations, "\n        "),
+		)
+		panic(message)
+	}
+
+	// The new notary seals the document along with all of its existing
+	// notaries, using the current algorithms of this digital notary.
+	v.notarizeDocument(document)
+	v.logDocument(document)
+	v.recordEvent("ResealDocument", document)
+}
+
+func (v *digitalNotary_) VerifyArchive(
+	document com.DocumentLike,
+	repository Resolving,
+	trusted []com.DocumentLike,
+) []string {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to verify an archived document",
+	)
+
+	if uti.IsUndefined(document) || !document.IsNotarized() {
+		panic("Only a notarized document may be verified.")
+	}
+	if uti.IsUndefined(repository) {
+		panic("The \"repository\" attribute is required by this method.")
+	}
+	if len(trusted) == 0 {
+		panic("The \"trusted\" attribute is required by this method.")
+	}
+
+	// Each trusted certificate binds its tag to the key of its first version.
+	var roots []com.DocumentLike
+	for _, certificate := range trusted {
+		var first = v.firstVersion(certificate, repository)
+		if uti.IsDefined(first) {
+			roots = append(roots, first)
+		}
+	}
+
+	// Peel off each notary to recover the document that it sealed.
+	var layers []com.DocumentLike
+	var layer = com.DocumentClass().DocumentFromSource(document.AsSource())
+	for layer.IsNotarized() {
+		layers = append([]com.DocumentLike{layer}, layers...)
+		layer = com.DocumentClass().DocumentFromSource(layer.AsSource())
+		layer.RemoveNotary()
+	}
+
+	// Each notary must seal the document and its earlier notaries while its
+	// certificate was current, and after the earlier notaries.
+	var violations []string
+	for index, layer := range layers {
+		violations = append(violations, v.checkLayer(index+1, layer, repository, roots)...)
+		if index > 0 && v.notaryTimestamp(layer) < v.notaryTimestamp(layers[index-1]) {
+			violations = append(violations, fmt.Sprintf(
+				"Notary %d is earlier than the notary it seals.",
+				index+1,
+			))
+		}
+	}
+	return violations
+}
+
+// Attribute Methods
+
+func (v *digitalNotary_) GetOptionalCertificate() com.DocumentLike {
+	return v.certificate_
+}
+
+func (v *digitalNotary_) SetAuditSink(
+	sink AuditSink,
+) {
+	v.sink_ = sink
+}
+
+func (v *digitalNotary_) SetTransparencyLog(
+	log Transparent,
+) {
+	v.log_ = log
+}
+
+func (v *digitalNotary_) SetLegacyModules(
+	ssms []Trusted,
+	hsms []Hardened,
+) {
+	v.legacySsms_ = ssms
+	v.legacyHsms_ = hsms
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *digitalNotary_) addAgreementKey(
+	identity com.IdentityLike,
+) {
+	// Publish the current agreement key of the HSM, if it has one, alongside
+	// the signing key so that they are rotated together.
+	var hsm, ok = v.hsm_.(Agreeing)
+	if ok {
+		identity.AddPublicKey(
+			"$agreementKey",
+			doc.Quote(`"`+hsm.GetAgreementAlgorithm()+`"`),
+			doc.Binary(hsm.GetAgreementKey()),
+		)
+	}
+}
+
+func (v *digitalNotary_) addNotary(
+	document com.DocumentLike,
+) {
+	// Check for new certificate document.
+	var owner doc.TagLike
+	var citation com.CitationLike
+	if uti.IsDefined(v.certificate_) {
+		owner = v.certificate_.GetContent().GetTag()
+		citation = v.CiteDocument(v.certificate_)
+	} else {
+		owner = doc.Tag() // Generate a new random tag.
+	}
+
+	// Add the notary attribute to the document.
+	var notary = com.NotaryClass().Notary(
+		owner,
+		citation,
+	)
+	document.AddNotary(notary)
+}
+
+func (v *digitalNotary_) addSeal(
+	document com.DocumentLike,
+	signature []byte,
+) {
+	var algorithm = doc.Quote(`"` + v.hsm_.GetSignatureAlgorithm() + `"`)
+	var seal = com.SealClass().SealWithFormat(
+		algorithm,
+		doc.Binary(signature),
+		com.CanonicalClass().Format(),
+	)
+	document.SetNotarySeal(seal)
+}
+
+func (v *digitalNotary_) agreementKey(
+	certificate com.DocumentLike,
+) []byte {
+	var identity = com.IdentityClass().IdentityFromSource(
+		certificate.GetContent().AsSource(),
+	)
+	var key = identity.GetOptionalPublicKey("$agreementKey")
+	if uti.IsUndefined(key) {
+		panic("The recipient certificate does not publish an agreement key.")
+	}
+	var algorithm = string(identity.GetOptionalKeyAlgorithm("$agreementKey").AsIntrinsic())
+	if algorithm != "X25519" {
+		var message = fmt.Sprintf(
+			"The recipient agreement key algorithm %q is not supported.",
+			algorithm,
+		)
+		panic(message)
+	}
+	return key.AsIntrinsic()
+}
+
+func (v *digitalNotary_) certifyKey(
+	bytes []byte,
+) com.DocumentLike {
+	var key = doc.Binary(bytes)
+	var algorithm = doc.Quote(`"` + v.hsm_.GetSignatureAlgorithm() + `"`)
+
+	// Create the new certificate document.
+	var content = v.certificate_.GetContent()
+	var identity = com.IdentityClass().IdentityFromSource(
+		content.AsSource(),
+	)
+	var attributes = identity.GetAttributes()
+	var tag = content.GetTag()
+	var version = doc.VersionClass().GetNextVersion(content.GetVersion(), 0)
+	var previous = v.CiteDocument(v.certificate_).AsResource()
+	var certificate = com.IdentityClass().Identity(
+		algorithm,
+		key,
+		attributes,
+		tag,
+		version,
+		previous,
+	)
+	v.addAgreementKey(certificate)
+	var document = com.DocumentClass().Document(certificate)
+
+	// Record the new certificate so that an interrupted rotation can be
+	// completed, and then notarize it using the previous key.
+	v.addNotary(document)
+	v.hsm_.RecordRotation([]byte(document.AsSource()))
+	var signature = v.hsm_.SignWithPreviousKey(document.AsCanonical())
+	v.addSeal(document, signature)
+	v.certificate_ = document
+	v.logDocument(document)
+	return document
+}
+
+func (v *digitalNotary_) certificateChain(
+	citation com.CitationLike,
+	repository Resolving,
+) []com.DocumentLike {
+	// Find the first version of the certificate.
+	var chain []com.DocumentLike
+	var versions = repository.RetrieveVersions(citation.GetTag())
+	for _, version := range versions {
+		if uti.IsUndefined(version.GetContent().GetOptionalPrevious()) {
+			chain = append(chain, version)
+			break
+		}
+	}
+	if len(chain) == 0 {
+		var message = fmt.Sprintf(
+			"The first version of certificate %s could not be retrieved.",
+			citation.GetTag().AsSource(),
+		)
+		panic(message)
+	}
+
+	// Follow the previous version citations forward to the latest version.
+	for {
+		var last = chain[len(chain)-1]
+		var next com.DocumentLike
+		for _, version := range versions {
+			var previous = version.GetContent().GetOptionalPrevious()
+			if uti.IsDefined(previous) &&
+				v.CitationMatches(com.CitationClass().CitationFromResource(previous), last) {
+				next = version
+				break
+			}
+		}
+		if uti.IsUndefined(next) {
+			break
+		}
+		chain = append(chain, next)
+	}
+
+	// The cited version must be part of the chain.
+	for _, certificate := range chain {
+		if v.CitationMatches(citation, certificate) {
+			return chain
+		}
+	}
+	var message = fmt.Sprintf(
+		"Version %s of certificate %s could not be retrieved.",
+		citation.GetVersion().AsSource(),
+		citation.GetTag().AsSource(),
+	)
+	panic(message)
+}
+
+func (v *digitalNotary_) chainTrusted(
+	chain []com.DocumentLike,
+	certificates []com.DocumentLike,
+) bool {
+	// Since each version in the chain is certified by its previous version,
+	// trusting any one of them binds the whole chain to its first version.
+	for _, trusted := range certificates {
+		var bytes = trusted.AsCanonical()
+		for _, certificate := range chain {
+			if byt.Equal(certificate.AsCanonical(), bytes) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (v *digitalNotary_) checkChain(
+	chain []com.DocumentLike,
+	status []com.CitationLike,
+) []string {
+	var violations []string
+	var tag = chain[0].GetContent().GetTag().AsSource()
+	if uti.IsDefined(chain[0].GetContent().GetOptionalPrevious()) ||
+		!v.SealMatches(chain[0], chain[0]) {
+		violations = append(violations, fmt.Sprintf(
+			"The first version of certificate %s is not self-signed.",
+			tag,
+		))
+	}
+	for index := 1; index < len(chain); index++ {
+		var certificate = chain[index]
+		var previous = certificate.GetContent().GetOptionalPrevious()
+		if uti.IsUndefined(previous) ||
+			!v.CitationMatches(com.CitationClass().CitationFromResource(previous), chain[index-1]) ||
+			!v.SealMatches(certificate, chain[index-1]) {
+			violations = append(violations, fmt.Sprintf(
+				"Version %s of certificate %s is not certified by its previous version.",
+				certificate.GetContent().GetVersion().AsSource(),
+				tag,
+			))
+		}
+	}
+	for _, citation := range status {
+		if citation.GetTag().AsSource() == tag {
+			if !v.CitationMatches(citation, chain[len(chain)-1]) {
+				violations = append(violations, fmt.Sprintf(
+					"The latest version of certificate %s is missing.",
+					tag,
+				))
+			}
+			return violations
+		}
+	}
+	return append(violations, fmt.Sprintf(
+		"The status of certificate %s is missing.",
+		tag,
+	))
+}
+
+func (v *digitalNotary_) checkLayer(
+	number int,
+	layer com.DocumentLike,
+	repository Resolving,
+	roots []com.DocumentLike,
+) []string {
+	// Only the first version of a certificate is sealed using itself.
+	var certificate = layer
+	var citation = layer.GetNotaryCitation()
+	if uti.IsDefined(citation) {
+		var digest = string(citation.GetAlgorithm().AsIntrinsic())
+		if uti.IsUndefined(v.ssmFor(digest)) {
+			return []string{fmt.Sprintf(
+				"Notary %d uses the unsupported %s digest algorithm.",
+				number,
+				digest,
+			)}
+		}
+		certificate = repository.RetrieveDocument(citation)
+		if uti.IsUndefined(certificate) || !v.CitationMatches(citation, certificate) {
+			return []string{fmt.Sprintf(
+				"The certificate for notary %d could not be retrieved.",
+				number,
+			)}
+		}
+	}
+	var type_ = certificate.GetContent().GetType().AsSource()
+	if type_ != "/bali/types/notary/Identity/v3" {
+		return []string{fmt.Sprintf(
+			"Notary %d does not cite the certificate that sealed it.",
+			number,
+		)}
+	}
+
+	// Each seal is verified by the HSM for the algorithm of its certificate.
+	var identity = com.IdentityClass().IdentityFromSource(
+		certificate.GetContent().AsSource(),
+	)
+	var algorithm = string(identity.GetAlgorithm().AsIntrinsic())
+	if uti.IsUndefined(v.hsmFor(algorithm)) {
+		return []string{fmt.Sprintf(
+			"Notary %d uses the unsupported %s algorithm.",
+			number,
+			algorithm,
+		)}
+	}
+
+	// The certificate must be a valid version of a trusted certificate, since
+	// anyone may write a certificate of their own into the repository.
+	var first = v.firstVersion(certificate, repository)
+	if uti.IsUndefined(first) {
+		return []string{fmt.Sprintf(
+			"The certificate chain for notary %d is invalid.",
+			number,
+		)}
+	}
+	if !v.chainTrusted([]com.DocumentLike{first}, roots) {
+		return []string{fmt.Sprintf(
+			"The certificate for notary %d is not trusted.",
+			number,
+		)}
+	}
+	if !v.SealMatches(layer, certificate) {
+		return []string{fmt.Sprintf(
+			"The seal for notary %d is invalid.",
+			number,
+		)}
+	}
+
+	// A certificate is superseded once its next version is notarized.
+	var tag = certificate.GetContent().GetTag()
+	for _, version := range repository.RetrieveVersions(tag) {
+		var previous = version.GetContent().GetOptionalPrevious()
+		if uti.IsDefined(previous) &&
+			v.CitationMatches(com.CitationClass().CitationFromResource(previous), certificate) &&
+			v.notaryTimestamp(layer) >= v.notaryTimestamp(version) {
+			return []string{fmt.Sprintf(
+				"Notary %d was sealed after its certificate was superseded.",
+				number,
+			)}
+		}
+	}
+	return nil
+}
+
+func (v *digitalNotary_) checkSeal(
+	name string,
+	document com.DocumentLike,
+	chains map[string][]com.DocumentLike,
+) []string {
+	var citation = document.GetNotaryCitation()
+	if uti.IsUndefined(citation) {
+		// Only the first version of a certificate is sealed using itself.
+		var type_ = document.GetContent().GetType().AsSource()
+		if type_ == "/bali/types/notary/Identity/v3" && v.SealMatches(document, document) {
+			return nil
+		}
+		return []string{"The " + name + " does not cite the certificate that sealed it."}
+	}
+	var chain = chains[citation.GetTag().AsSource()]
+	for index, certificate := range chain {
+		if !v.CitationMatches(citation, certificate) {
+			continue
+		}
+		if !v.SealMatches(document, certificate) {
+			return []string{"The seal on the " + name + " is invalid."}
+		}
+
+		// A certificate is superseded once its next version is notarized.
+		if index+1 < len(chain) &&
+			v.notaryTimestamp(document) >= v.notaryTimestamp(chain[index+1]) {
+			return []string{"The " + name + " was sealed after its certificate was superseded."}
+		}
+		return nil
+	}
+	return []string{"The certificate that sealed the " + name + " is missing."}
+}
+
+func (v *digitalNotary_) deriveKey(
+	secret []byte,
+	ephemeralKey []byte,
+	recipientKey []byte,
+) []byte {
+	var salt = append(append([]byte{}, ephemeralKey...), recipientKey...)
+	var info = "/bali/types/notary/Envelope/v3"
+	var key, err = hkd.Key(dig.New, secret, salt, info, 32)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func (v *digitalNotary_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"DigitalNotary: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *digitalNotary_) firstVersion(
+	certificate com.DocumentLike,
+	repository Resolving,
+) (
+	first com.DocumentLike,
+) {
+	// An invalid certificate chain results in an undefined first version.
+	defer func() {
+		if e := recover(); e != nil {
+			first = nil
+		}
+	}()
+	first = v.verifyChain(certificate, repository)
+	return
+}
+
+func (v *digitalNotary_) hsmFor(
+	algorithm string,
+) Hardened {
+	// The current HSM is preferred over any legacy HSM for the same algorithm.
+	if v.hsm_.GetSignatureAlgorithm() == algorithm {
+		return v.hsm_
+	}
+	for _, hsm := range v.legacyHsms_ {
+		if hsm.GetSignatureAlgorithm() == algorithm {
+			return hsm
+		}
+	}
+	return nil
+}
+
+func (v *digitalNotary_) logDocument(
+	document com.DocumentLike,
+) {
+	// Any documents notarized by the transparency log itself (e.g. its tree
+	// heads) are not logged.
+	if uti.IsUndefined(v.log_) || v.logging_ {
+		return
+	}
+	v.logging_ = true
+	defer func() { v.logging_ = false }()
+
+	// Log the sealed document and add the inclusion proof to its last notary.
+	var proof = v.log_.AppendCitation(v.CiteDocument(document))
+	document.SetNotaryProof(proof)
+}
+
+func (v *digitalNotary_) notarizeDocument(
+	document com.DocumentLike,
+) {
+	// Digitally sign the document using the current key.
+	v.addNotary(document)
+	var signature = v.hsm_.SignBytes(document.AsCanonical())
+	v.addSeal(document, signature)
+}
+
+func (v *digitalNotary_) notarizePayload(
+	document com.DocumentLike,
+	format doc.NameLike,
+) com.DocumentLike {
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+	if uti.IsUndefined(document) || !document.IsNotarized() {
+		panic("Only a notarized document may be exported.")
+	}
+
+	// The payload is the notarized document, the key identifier is a citation
+	// to the current certificate and the timestamp is in whole seconds.
+	var resource = v.CiteDocument(v.certificate_).AsResource().AsSource()
+	var keyID = resource[1 : len(resource)-1] // Remove the angle brackets.
+	var timestamp = doc.Moment(doc.Moment().AsIntrinsic() / 1000 * 1000)
+	var entity = com.PayloadClass().Payload(
+		com.PayloadClass().ProtectedHeader(format, keyID, timestamp),
+		doc.Binary([]byte(document.AsSource())),
+		doc.Tag(),
+		doc.Version(),
+		nil,
+	)
+	var payload = com.DocumentClass().Document(entity)
+
+	// Sign the bytes defined by the format rather than the canonical bytes.
+	v.addNotary(payload)
+	var bytes = com.CanonicalClass().FormatBytes(payload.AsIntrinsic(), format)
+	var algorithm = doc.Quote(`"` + v.hsm_.GetSignatureAlgorithm() + `"`)
+	var seal = com.SealClass().SealWithFormat(
+		algorithm,
+		doc.Binary(v.hsm_.SignBytes(bytes)),
+		format,
+	)
+	payload.SetNotarySeal(seal)
+
+	// The signed structure is imported again so that the resulting document
+	// only contains the values that are covered by the signature.
+	if format.AsSource() == com.CanonicalClass().JWSFormat().AsSource() {
+		return com.DocumentClass().DocumentFromJWS(payload.AsJWS())
+	}
+	return com.DocumentClass().DocumentFromCOSE(payload.AsCOSE())
+}
+
+func (v *digitalNotary_) notarizeNextVersion(
+	previous com.DocumentLike,
+	entity any,
+	level int,
+) com.DocumentLike {
+	// Make sure the digital notary has been initialized.
+	if uti.IsUndefined(v.certificate_) {
+		panic("The digital notary has not yet been initialized.")
+	}
+	if uti.IsUndefined(previous) {
+		panic("The \"previous\" attribute is required by this method.")
+	}
+	if level < LastLevel {
+		panic("The \"level\" attribute must not be negative.")
+	}
+
+	// Create the next version of the content using the same type, tag and
+	// permissions as the previous version.
+	var citation = v.CiteDocument(previous).AsResource()
+	var content = previous.GetContent()
+	var type_ = content.GetType()
+	var tag = content.GetTag()
+	var version = doc.VersionClass().GetNextVersion(
+		content.GetVersion(),
+		uint(level),
+	)
+	var permissions = content.GetPermissions()
+	var next = com.ContentClass().Content(
+		entity,
+		type_,
+		tag,
+		version,
+		permissions,
+		citation,
+	)
+	var document = com.DocumentClass().Document(next)
+
+	// Notarize the next version of the document.
+	v.notarizeDocument(document)
+
+	return document
+}
+
+func (v *digitalNotary_) notaryTimestamp(
+	document com.DocumentLike,
+) int {
+	var component = document.GetSubcomponent(
+		doc.Symbol("$notaries"),
+		-1, // The last notary seal.
+	)
+	var notary = com.NotaryClass().NotaryFromSource(doc.FormatComponent(component))
+	return notary.GetTimestamp().AsIntrinsic()
+}
+
+func (v *digitalNotary_) openBytes(
+	key []byte,
+	bytes []byte,
+	data []byte,
+) []byte {
+	var block, err = aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cip.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	var size = aead.NonceSize()
+	if len(bytes) < size {
+		panic("The encrypted bytes are too short.")
+	}
+	plaintext, err := aead.Open(nil, bytes[:size], bytes[size:], data)
+	if err != nil {
+		panic("The encrypted bytes could not be authenticated.")
+	}
+	return plaintext
+}
+
+func (v *digitalNotary_) payloadMatches(
+	document com.DocumentLike,
+	seal com.SealLike,
+) bool {
+	// A JWS or COSE signature only covers the payload and its protected header,
+	// so the rest of the document must contain only the values derived from
+	// them.
+	var format = seal.GetOptionalFormat()
+	if uti.IsUndefined(format) {
+		return true
+	}
+	var class = com.CanonicalClass()
+	switch format.AsSource() {
+	case class.JWSFormat().AsSource():
+		var expected = com.DocumentClass().DocumentFromJWS(document.AsJWS())
+		return expected.AsSource() == document.AsSource()
+	case class.COSEFormat().AsSource():
+		var expected = com.DocumentClass().DocumentFromCOSE(document.AsCOSE())
+		return expected.AsSource() == document.AsSource()
+	default:
+		return true
+	}
+}
+
+func (v *digitalNotary_) pendingCertificate() com.DocumentLike {
+	// The recorded certificate is only pending if it is the next version of
+	// the current certificate.
+	var record = v.hsm_.GetRotationRecord()
+	if uti.IsUndefined(record) {
+		return nil
+	}
+	var certificate = com.DocumentClass().DocumentFromSource(string(record))
+	var previous = certificate.GetContent().GetOptionalPrevious()
+	if uti.IsUndefined(previous) ||
+		!v.CitationMatches(com.CitationClass().CitationFromResource(previous), v.certificate_) {
+		return nil
+	}
+	return certificate
+}
+
+func (v *digitalNotary_) recordEvent(
+	operation string,
+	optionalDocument com.DocumentLike,
+) {
+	// The audit sink is responsible for ignoring the events caused by its own
+	// operations (e.g. notarizing its own entries using this digital notary).
+	if uti.IsUndefined(v.sink_) {
+		return
+	}
+
+	// Only a citation to the document is passed to the audit sink so that the
+	// contents of private documents are never recorded.
+	var subject com.CitationLike
+	if uti.IsDefined(optionalDocument) {
+		subject = v.CiteDocument(optionalDocument)
+	}
+	v.sink_.RecordEvent(operation, subject)
+}
+
+func (c *digitalNotaryClass_) restoreNotary(
+	ssm Trusted,
+	hsm Hardened,
+	certificate com.DocumentLike,
+	repository Resolving,
+) DigitalNotaryLike {
+	// Create the new digital notary.
+	var instance = &digitalNotary_{
+		// Initialize the instance attributes.
+		ssm_:         ssm,
+		hsm_:         hsm,
+		certificate_: certificate,
+	}
+
+	// Make sure the certificate is for the key pair in the HSM.  If a key
+	// rotation was interrupted the certificate is for the previous key pair,
+	// or it is cited by the recorded certificate for the new key pair.
+	var keyBytes = hsm.GetPublicKey()
+	var previousBytes = hsm.GetPreviousKey()
+	if uti.IsDefined(previousBytes) {
+		keyBytes = previousBytes
+	}
+	var identity = com.IdentityClass().IdentityFromSource(
+		certificate.GetContent().AsSource(),
+	)
+	if !byt.Equal(keyBytes, identity.GetKey().AsIntrinsic()) &&
+		(uti.IsDefined(previousBytes) || uti.IsUndefined(instance.pendingCertificate())) {
+		var message = fmt.Sprintf(
+			"The \"certificate\" document does not match the HSM key: %s\n",
+			certificate.AsSource(),
+		)
+		panic(message)
+	}
+
+	// Validate the seal on the certificate document.  The first version is
+	// sealed using its own key and each later version using the key from its
+	// previous version, which must be retrieved from the repository.
+	switch {
+	case uti.IsUndefined(identity.GetOptionalPrevious()):
+		if uti.IsDefined(certificate.GetNotaryCitation()) ||
+			!instance.SealMatches(certificate, certificate) {
+			var message = fmt.Sprintf(
+				"The \"certificate\" document is invalid: %s\n",
+				certificate.AsSource(),
+			)
+			panic(message)
+		}
+	case uti.IsUndefined(repository):
+		panic("A repository is required to validate a certificate that is not self-signed.")
+	}
+
+	// Make sure the certificate is the latest version of a valid chain of
+	// certificates.
+	if uti.IsDefined(repository) {
+		instance.verifyCertificate(certificate, repository)
+	}
+	return instance
+}
+
+func (c *digitalNotaryClass_) signedBytes(
+	document com.DocumentLike,
+) (
+	seal com.SealLike,
+	bytes []byte,
+) {
+	// The seal and any transparency log proof were added after the document
+	// was signed.
+	var proof = document.RemoveNotaryProof()
+	seal = document.RemoveNotarySeal()
+	bytes = com.CanonicalClass().FormatBytes(
+		document.AsIntrinsic(),
+		seal.GetOptionalFormat(),
+	)
+	document.SetNotarySeal(seal)
+	if uti.IsDefined(proof) {
+		document.SetNotaryProof(proof)
+	}
+	return seal, bytes
+}
+
+func (v *digitalNotary_) sealBytes(
+	key []byte,
+	bytes []byte,
+	data []byte,
+) []byte {
+	var block, err = aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cip.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	var nonce = make([]byte, aead.NonceSize())
+	ran.Read(nonce)
+	return aead.Seal(nonce, nonce, bytes, data)
+}
+
+func (v *digitalNotary_) ssmFor(
+	algorithm string,
+) Trusted {
+	// The current SSM is preferred over any legacy SSM for the same algorithm.
+	if v.ssm_.GetDigestAlgorithm() == algorithm {
+		return v.ssm_
+	}
+	for _, ssm := range v.legacySsms_ {
+		if ssm.GetDigestAlgorithm() == algorithm {
+			return ssm
+		}
+	}
+	return nil
+}
+
+func (v *digitalNotary_) verifyCertificate(
+	certificate com.DocumentLike,
+	repository Resolving,
+) {
+	// A certificate that has been superseded may no longer be used.
+	var tag = certificate.GetContent().GetTag()
+	for _, version := range repository.RetrieveVersions(tag) {
+		var previous = version.GetContent().GetOptionalPrevious()
+		if uti.IsDefined(previous) &&
+			v.CitationMatches(com.CitationClass().CitationFromResource(previous), certificate) {
+			var message = fmt.Sprintf(
+				"The certificate has been superseded by version %s.",
+				version.GetContent().GetVersion().AsSource(),
+			)
+			panic(message)
+		}
+	}
+
+	// The rest of the certificate chain must also be valid.
+	v.verifyChain(certificate, repository)
+}
+
+func (v *digitalNotary_) verifyChain(
+	certificate com.DocumentLike,
+	repository Resolving,
+) com.DocumentLike {
+	// Each version of the certificate must be certified by its previous
+	// version, back to the first version which is sealed using itself.
+	var current = certificate
+	for {
+		var version = current.GetContent().GetVersion().AsSource()
+		var previous = current.GetContent().GetOptionalPrevious()
+		if uti.IsUndefined(previous) {
+			if uti.IsDefined(current.GetNotaryCitation()) || !v.SealMatches(current, current) {
+				panic("The first version of the certificate is not self-signed.")
+			}
+			return current
+		}
+		var citation = com.CitationClass().CitationFromResource(previous)
+		var prior = repository.RetrieveDocument(citation)
+		if uti.IsUndefined(prior) || !v.CitationMatches(citation, prior) {
+			var message = fmt.Sprintf(
+				"The previous version of certificate version %s could not be retrieved.",
+				version,
+			)
+			panic(message)
+		}
+		var notary = current.GetNotaryCitation()
+		if uti.IsUndefined(notary) || !v.CitationMatches(notary, prior) ||
+			!v.SealMatches(current, prior) {
+			var message = fmt.Sprintf(
+				"Version %s of the certificate is not certified by its previous version.",
+				version,
+			)
+			panic(message)
+		}
+		current = prior
+	}
 }
 
 // Instance Structure
@@ -432,12 +1845,18 @@ type digitalNotary_ struct {
 	ssm_         Trusted
 	hsm_         Hardened
 	certificate_ com.DocumentLike
+	sink_        AuditSink
+	log_         Transparent
+	logging_     bool
+	legacySsms_  []Trusted
+	legacyHsms_  []Hardened
 }
 
 // Class Structure
 
 type digitalNotaryClass_ struct {
 	// Declare the class constants.
+	encryption_ string
 }
 
 // Class Reference
@@ -448,4 +1867,5 @@ func digitalNotaryClass() *digitalNotaryClass_ {
 
 var digitalNotaryClassReference_ = &digitalNotaryClass_{
 	// Initialize the class constants.
+	encryption_: "X25519-AES256GCM",
 }
@@ -0,0 +1,315 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func GuardClass() GuardClassLike {
+	return guardClass()
+}
+
+// Constructor Methods
+
+func (c *guardClass_) Guard(
+	notary DigitalNotaryLike,
+	repository Resolving,
+	certificates []com.DocumentLike,
+) GuardLike {
+	if uti.IsUndefined(notary) {
+		panic("The \"notary\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(repository) {
+		panic("The \"repository\" attribute is required by this class.")
+	}
+	var instance = &guard_{
+		// Initialize the instance attributes.
+		notary_:      notary,
+		repository_:  repository,
+		trusted_:     make(map[string]com.DocumentLike),
+		owners_:      make(map[string]doc.TagLike),
+		permissions_: make(map[string]com.DocumentLike),
+	}
+
+	// Each certificate tag is bound to the chain of a trusted certificate.
+	for _, certificate := range certificates {
+		if !notary.CertificateMatches(certificate, certificate, repository) {
+			var message = fmt.Sprintf(
+				"The trusted certificate is not valid: %s",
+				certificate.AsSource(),
+			)
+			panic(message)
+		}
+		var tag = certificate.GetContent().GetTag().AsSource()
+		instance.trusted_[tag] = certificate
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *guard_) GetClass() GuardClassLike {
+	return guardClass()
+}
+
+// Attribute Methods
+
+func (v *guard_) SetOwner(
+	name doc.NameLike,
+	owner doc.TagLike,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to set the owner of permissions",
+	)
+
+	var key = name.AsSource()
+	if _, ok := v.owners_[key]; ok {
+		panic("The permissions already have an owner.")
+	}
+	if _, ok := v.trusted_[owner.AsSource()]; !ok {
+		panic("The owner of the permissions must be a trusted certificate.")
+	}
+	v.owners_[key] = owner
+}
+
+func (v *guard_) GetOptionalOwner(
+	name doc.NameLike,
+) doc.TagLike {
+	return v.owners_[name.AsSource()]
+}
+
+func (v *guard_) AddPermissions(
+	document com.DocumentLike,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to add permissions",
+	)
+
+	// Make sure the document contains valid permissions.
+	var content = document.GetContent()
+	if content.GetType().AsSource() != "/bali/types/notary/Permissions/v3" {
+		panic("The document does not contain permissions.")
+	}
+	var permissions = com.PermissionsClass().PermissionsFromSource(
+		content.AsSource(),
+	)
+	var name = permissions.GetName().AsSource()
+	var class = com.PermissionsClass()
+	if name == class.Public().AsSource() || name == class.Private().AsSource() {
+		panic("The public and private permissions cannot be redefined.")
+	}
+
+	// Make sure the permissions were notarized by a known certificate.
+	var certificate = v.verifyDocument(document)
+	if uti.IsUndefined(certificate) {
+		panic("The permissions document was not validly notarized.")
+	}
+
+	// The first version of the permissions must be notarized by their owner.
+	var owner, ok = v.owners_[name]
+	if !ok {
+		panic("The permissions do not have a trusted owner.")
+	}
+	var current, found = v.permissions_[name]
+	if !found && certificate.AsSource() != owner.AsSource() {
+		panic("The permissions were not notarized by their owner.")
+	}
+
+	// Any existing permissions may only be replaced by a later version that
+	// cites them and was notarized by a certificate allowed to write them.
+	if found {
+		if !v.isGranted(current, certificate, "$write") {
+			panic("The certificate is not allowed to replace the permissions.")
+		}
+		var previous = permissions.GetOptionalPrevious()
+		if uti.IsUndefined(previous) {
+			panic("The permissions do not cite the current permissions.")
+		}
+		var citation = com.CitationClass().CitationFromResource(previous)
+		if !v.notary_.CitationMatches(citation, current) {
+			panic("The permissions do not cite the current permissions.")
+		}
+	}
+	v.permissions_[name] = document
+}
+
+func (v *guard_) GetOptionalPermissions(
+	name doc.NameLike,
+) com.PermissionsLike {
+	var permissions com.PermissionsLike
+	var document, ok = v.permissions_[name.AsSource()]
+	if ok {
+		permissions = com.PermissionsClass().PermissionsFromSource(
+			document.GetContent().AsSource(),
+		)
+	}
+	return permissions
+}
+
+func (v *guard_) CheckAccess(
+	credential com.DocumentLike,
+	document com.DocumentLike,
+	action string,
+) bool {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to check access",
+	)
+
+	// Validate the arguments.
+	if uti.IsUndefined(credential) {
+		panic("A credential is required to check access.")
+	}
+	if uti.IsUndefined(document) {
+		panic("A document is required to check access.")
+	}
+	switch action {
+	case "$read", "$write", "$notarize":
+	default:
+		panic("The action must be one of $read, $write or $notarize: " + action)
+	}
+
+	// Determine which certificate the credential was notarized with.
+	var type_ = credential.GetContent().GetType().AsSource()
+	if type_ != "/bali/types/notary/Credential/v3" {
+		return false
+	}
+	var certificate = v.verifyDocument(credential)
+	if uti.IsUndefined(certificate) {
+		return false
+	}
+
+	// Evaluate the permissions of the document for that certificate.
+	return v.isGranted(document, certificate, action)
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *guard_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"Guard: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *guard_) isGranted(
+	document com.DocumentLike,
+	certificate doc.TagLike,
+	action string,
+) bool {
+	// The owner of a document may always access it.
+	if document.IsNotarized() {
+		var citation = document.GetNotaryCitation()
+		if uti.IsDefined(citation) &&
+			citation.GetTag().AsSource() == certificate.AsSource() {
+			return true
+		}
+	}
+
+	// Anyone may read a public document but only its owner may access a
+	// private document.
+	var name = document.GetContent().GetPermissions()
+	var class = com.PermissionsClass()
+	switch name.AsSource() {
+	case class.Public().AsSource():
+		return action == "$read"
+	case class.Private().AsSource():
+		return false
+	}
+
+	// Any other permissions must have been added to the guard.
+	var permissions = v.GetOptionalPermissions(name)
+	if uti.IsUndefined(permissions) {
+		return false
+	}
+	return permissions.IsGranted(action, certificate)
+}
+
+func (v *guard_) verifyDocument(
+	document com.DocumentLike,
+) doc.TagLike {
+	// Retrieve the certificate that notarized the document.
+	if !document.IsNotarized() {
+		return nil
+	}
+	var citation = document.GetNotaryCitation()
+	if uti.IsUndefined(citation) {
+		return nil
+	}
+	var certificate = v.repository_.RetrieveDocument(citation)
+	if uti.IsUndefined(certificate) ||
+		!v.notary_.CitationMatches(citation, certificate) {
+		return nil
+	}
+
+	// The certificate must be a valid version of a trusted certificate with
+	// the same tag, and it must have sealed the document.
+	var trusted, ok = v.trusted_[citation.GetTag().AsSource()]
+	if !ok ||
+		!v.notary_.CertificateMatches(certificate, trusted, v.repository_) ||
+		!v.notary_.SealMatches(document, certificate) {
+		return nil
+	}
+	return citation.GetTag()
+}
+
+// Instance Structure
+
+type guard_ struct {
+	// Declare the instance attributes.
+	notary_      DigitalNotaryLike
+	repository_  Resolving
+	trusted_     map[string]com.DocumentLike
+	owners_      map[string]doc.TagLike
+	permissions_ map[string]com.DocumentLike
+}
+
+// Class Structure
+
+type guardClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func guardClass() *guardClass_ {
+	return guardClassReference_
+}
+
+var guardClassReference_ = &guardClass_{
+	// Initialize the class constants.
+}
@@ -0,0 +1,265 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	byt "bytes"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func HistoryClass() HistoryClassLike {
+	return historyClass()
+}
+
+// Constructor Methods
+
+func (c *historyClass_) History(
+	notary DigitalNotaryLike,
+	repository Resolving,
+	document com.DocumentLike,
+) HistoryLike {
+	if uti.IsUndefined(notary) {
+		panic("The \"notary\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(repository) {
+		panic("The \"repository\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(document) {
+		panic("The \"document\" attribute is required by this class.")
+	}
+	var instance = &history_{
+		// Initialize the instance attributes.
+		notary_:     notary,
+		repository_: repository,
+		document_:   document,
+	}
+	instance.traceVersions()
+	instance.detectForks()
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *history_) GetClass() HistoryClassLike {
+	return historyClass()
+}
+
+func (v *history_) IsValid() bool {
+	return len(v.violations_) == 0
+}
+
+// Attribute Methods
+
+func (v *history_) GetDocument() com.DocumentLike {
+	return v.document_
+}
+
+func (v *history_) GetVersions() []com.DocumentLike {
+	return v.versions_
+}
+
+func (v *history_) GetForks() []com.DocumentLike {
+	return v.forks_
+}
+
+func (v *history_) GetViolations() []string {
+	return v.violations_
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *history_) addFork(
+	fork com.DocumentLike,
+	version com.DocumentLike,
+) {
+	v.forks_ = append(v.forks_, fork)
+	v.addViolation(
+		"Version %s forks the history at version %s.",
+		fork.GetContent().GetVersion().AsSource(),
+		version.GetContent().GetVersion().AsSource(),
+	)
+}
+
+func (v *history_) addViolation(
+	format string,
+	arguments ...any,
+) {
+	var violation = fmt.Sprintf(format, arguments...)
+	v.violations_ = append(v.violations_, violation)
+}
+
+func (v *history_) contains(
+	document com.DocumentLike,
+) bool {
+	var bytes = document.AsCanonical()
+	for _, version := range v.versions_ {
+		if byt.Equal(bytes, version.AsCanonical()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *history_) detectForks() {
+	// Any other document that claims a version in the history as its previous
+	// version forks the history.  A single successor to the last version simply
+	// continues the history.
+	var last = len(v.versions_) - 1
+	var successors []com.DocumentLike
+	var tag = v.document_.GetContent().GetTag()
+	for _, candidate := range v.repository_.RetrieveVersions(tag) {
+		if v.contains(candidate) {
+			continue
+		}
+		var previous = candidate.GetContent().GetOptionalPrevious()
+		if uti.IsUndefined(previous) {
+			continue
+		}
+		var citation = v.parseCitation(previous)
+		if uti.IsUndefined(citation) {
+			continue
+		}
+		for index, version := range v.versions_ {
+			if v.notary_.CitationMatches(citation, version) {
+				if index == last {
+					successors = append(successors, candidate)
+				} else {
+					v.addFork(candidate, version)
+				}
+				break
+			}
+		}
+	}
+	if len(successors) > 1 {
+		for _, successor := range successors {
+			v.addFork(successor, v.versions_[last])
+		}
+	}
+}
+
+func (v *history_) parseCitation(
+	resource doc.ResourceLike,
+) (citation com.CitationLike) {
+	defer func() {
+		if e := recover(); e != nil {
+			v.addViolation("%v", e)
+		}
+	}()
+	citation = com.CitationClass().CitationFromResource(resource)
+	return citation
+}
+
+func (v *history_) traceVersions() {
+	// Follow the previous version citations back to the first version.
+	var current = v.document_
+	v.versions_ = []com.DocumentLike{current}
+	for {
+		var content = current.GetContent()
+		var version = content.GetVersion()
+		var previous = content.GetOptionalPrevious()
+		if uti.IsUndefined(previous) {
+			break
+		}
+		var citation = v.parseCitation(previous)
+		if uti.IsUndefined(citation) {
+			break
+		}
+		if citation.GetTag().AsSource() != content.GetTag().AsSource() {
+			v.addViolation(
+				"Version %s cites a previous version with a different tag.",
+				version.AsSource(),
+			)
+			break
+		}
+
+		// Retrieve and verify the previous version.
+		var prior = v.repository_.RetrieveDocument(citation)
+		if uti.IsUndefined(prior) {
+			v.addViolation(
+				"The previous version %s of version %s could not be found.",
+				citation.GetVersion().AsSource(),
+				version.AsSource(),
+			)
+			break
+		}
+		var priorVersion = prior.GetContent().GetVersion()
+		if !v.notary_.CitationMatches(citation, prior) {
+			v.addViolation(
+				"The digest of version %s does not match the citation in version %s.",
+				priorVersion.AsSource(),
+				version.AsSource(),
+			)
+		}
+		if v.contains(prior) {
+			v.addViolation(
+				"The history loops back to version %s.",
+				priorVersion.AsSource(),
+			)
+			break
+		}
+		if !doc.VersionClass().IsValidNextVersion(priorVersion, version) {
+			v.addViolation(
+				"There is a gap between version %s and version %s.",
+				priorVersion.AsSource(),
+				version.AsSource(),
+			)
+		}
+
+		// Prepend the previous version so that the oldest version is first.
+		v.versions_ = append([]com.DocumentLike{prior}, v.versions_...)
+		current = prior
+	}
+}
+
+// Instance Structure
+
+type history_ struct {
+	// Declare the instance attributes.
+	notary_     DigitalNotaryLike
+	repository_ Resolving
+	document_   com.DocumentLike
+	versions_   []com.DocumentLike
+	forks_      []com.DocumentLike
+	violations_ []string
+}
+
+// Class Structure
+
+type historyClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func historyClass() *historyClass_ {
+	return historyClassReference_
+}
+
+var historyClassReference_ = &historyClass_{
+	// Initialize the class constants.
+}
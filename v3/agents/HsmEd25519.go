@@ -119,6 +119,64 @@ func (v *hsmEd25519_) RotateKeys() []byte {
 	panic("This module has not yet been implemented.")
 }
 
+func (v *hsmEd25519_) GetPreviousKey() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to retrieve the previous key",
+	)
+
+	panic("This module has not yet been implemented.")
+}
+
+func (v *hsmEd25519_) RecordRotation(
+	record []byte,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to record a key rotation",
+	)
+
+	panic("This module has not yet been implemented.")
+}
+
+func (v *hsmEd25519_) GetRotationRecord() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to retrieve the rotation record",
+	)
+
+	panic("This module has not yet been implemented.")
+}
+
+func (v *hsmEd25519_) SignWithPreviousKey(
+	bytes []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to sign bytes with the previous key",
+	)
+
+	panic("This module has not yet been implemented.")
+}
+
+func (v *hsmEd25519_) GetRotationSignature() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to retrieve the rotation signature",
+	)
+
+	panic("This module has not yet been implemented.")
+}
+
+func (v *hsmEd25519_) AbortRotation() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to abort a key rotation",
+	)
+
+	panic("This module has not yet been implemented.")
+}
+
 func (v *hsmEd25519_) EraseKeys() {
 	// Check for any errors at the end.
 	defer v.errorCheck(
@@ -0,0 +1,323 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	byt "bytes"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func MerkleTreeClass() MerkleTreeClassLike {
+	return merkleTreeClass()
+}
+
+// Constructor Methods
+
+func (c *merkleTreeClass_) MerkleTree(
+	ssm Trusted,
+) MerkleTreeLike {
+	if uti.IsUndefined(ssm) {
+		panic("The \"ssm\" attribute is required by this class.")
+	}
+	var instance = &merkleTree_{
+		// Initialize the instance attributes.
+		ssm_: ssm,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *merkleTree_) GetClass() MerkleTreeClassLike {
+	return merkleTreeClass()
+}
+
+func (v *merkleTree_) AddLeaf(
+	leaf []byte,
+) uint {
+	var index = v.GetSize()
+	var hash = v.hashLeaf(leaf)
+
+	// Cache the hash of each complete subtree that is completed by the leaf.
+	for level := 0; ; level++ {
+		if level == len(v.levels_) {
+			v.levels_ = append(v.levels_, nil)
+		}
+		v.levels_[level] = append(v.levels_[level], hash)
+		var count = len(v.levels_[level])
+		if count&1 == 1 {
+			break
+		}
+		hash = v.hashNodes(v.levels_[level][count-2], hash)
+	}
+	return index
+}
+
+func (v *merkleTree_) GetRoot(
+	size uint,
+) []byte {
+	v.checkSize(size)
+	return v.hashRange(0, size)
+}
+
+func (v *merkleTree_) ProveInclusion(
+	index uint,
+	size uint,
+) [][]byte {
+	v.checkSize(size)
+	if index >= size {
+		panic("The index must be less than the size of the tree.")
+	}
+	return v.inclusionPath(index, 0, size)
+}
+
+func (v *merkleTree_) ProveConsistency(
+	first uint,
+	second uint,
+) [][]byte {
+	v.checkSize(second)
+	if first == 0 || first > second {
+		panic("The first size must be in the range [1..second].")
+	}
+	return v.consistencyPath(first, 0, second, true)
+}
+
+func (v *merkleTree_) VerifyInclusion(
+	leaf []byte,
+	index uint,
+	size uint,
+	path [][]byte,
+	root []byte,
+) bool {
+	// This is the verification algorithm from RFC 9162 section 2.1.3.2.
+	if index >= size {
+		return false
+	}
+	var fn = index
+	var sn = size - 1
+	var hash = v.hashLeaf(leaf)
+	for _, node := range path {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			hash = v.hashNodes(node, hash)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			hash = v.hashNodes(hash, node)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 && byt.Equal(hash, root)
+}
+
+func (v *merkleTree_) VerifyConsistency(
+	first uint,
+	second uint,
+	firstRoot []byte,
+	secondRoot []byte,
+	path [][]byte,
+) bool {
+	// This is the verification algorithm from RFC 9162 section 2.1.4.2.
+	if first == 0 || first > second {
+		return false
+	}
+	if first == second {
+		return len(path) == 0 && byt.Equal(firstRoot, secondRoot)
+	}
+	if first&(first-1) == 0 {
+		// The first tree is a complete subtree of the second tree.
+		path = append([][]byte{firstRoot}, path...)
+	}
+	if len(path) == 0 {
+		return false
+	}
+	var fn = first - 1
+	var sn = second - 1
+	for fn&1 == 1 {
+		fn >>= 1
+		sn >>= 1
+	}
+	var firstHash = path[0]
+	var secondHash = path[0]
+	for _, node := range path[1:] {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			firstHash = v.hashNodes(node, firstHash)
+			secondHash = v.hashNodes(node, secondHash)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			secondHash = v.hashNodes(secondHash, node)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 &&
+		byt.Equal(firstHash, firstRoot) &&
+		byt.Equal(secondHash, secondRoot)
+}
+
+// Attribute Methods
+
+func (v *merkleTree_) GetSize() uint {
+	if len(v.levels_) == 0 {
+		return 0
+	}
+	return uint(len(v.levels_[0]))
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *merkleTree_) checkSize(
+	size uint,
+) {
+	if size > v.GetSize() {
+		panic("The size is larger than the size of the tree.")
+	}
+}
+
+func (v *merkleTree_) consistencyPath(
+	first uint,
+	start uint,
+	end uint,
+	complete bool,
+) [][]byte {
+	// This is the SUBPROOF algorithm from RFC 9162 section 2.1.4.1.
+	if first == end-start {
+		if complete {
+			return nil
+		}
+		return [][]byte{v.hashRange(start, end)}
+	}
+	var split = v.splitPoint(end - start)
+	if first <= split {
+		var path = v.consistencyPath(first, start, start+split, complete)
+		return append(path, v.hashRange(start+split, end))
+	}
+	var path = v.consistencyPath(first-split, start+split, end, false)
+	return append(path, v.hashRange(start, start+split))
+}
+
+func (v *merkleTree_) hashLeaf(
+	leaf []byte,
+) []byte {
+	var bytes = append([]byte{0x00}, leaf...)
+	return v.ssm_.DigestBytes(bytes)
+}
+
+func (v *merkleTree_) hashNodes(
+	left []byte,
+	right []byte,
+) []byte {
+	var bytes = append([]byte{0x01}, left...)
+	bytes = append(bytes, right...)
+	return v.ssm_.DigestBytes(bytes)
+}
+
+func (v *merkleTree_) hashRange(
+	start uint,
+	end uint,
+) []byte {
+	var size = end - start
+	if size == 0 {
+		return v.ssm_.DigestBytes([]byte{})
+	}
+
+	// The hash of a complete subtree is cached when its last leaf is added.
+	if size&(size-1) == 0 && start%size == 0 {
+		var level = 0
+		for size>>level > 1 {
+			level++
+		}
+		return v.levels_[level][start/size]
+	}
+	var split = v.splitPoint(size)
+	return v.hashNodes(
+		v.hashRange(start, start+split),
+		v.hashRange(start+split, end),
+	)
+}
+
+func (v *merkleTree_) inclusionPath(
+	index uint,
+	start uint,
+	end uint,
+) [][]byte {
+	// This is the PATH algorithm from RFC 9162 section 2.1.3.1.
+	if end-start == 1 {
+		return nil
+	}
+	var split = v.splitPoint(end - start)
+	if index < split {
+		var path = v.inclusionPath(index, start, start+split)
+		return append(path, v.hashRange(start+split, end))
+	}
+	var path = v.inclusionPath(index-split, start+split, end)
+	return append(path, v.hashRange(start, start+split))
+}
+
+func (v *merkleTree_) splitPoint(
+	size uint,
+) uint {
+	// Return the largest power of two that is less than the size.
+	var split uint = 1
+	for split<<1 < size {
+		split <<= 1
+	}
+	return split
+}
+
+// Instance Structure
+
+type merkleTree_ struct {
+	// Declare the instance attributes.
+	ssm_    Trusted
+	levels_ [][][]byte
+}
+
+// Class Structure
+
+type merkleTreeClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func merkleTreeClass() *merkleTreeClass_ {
+	return merkleTreeClassReference_
+}
+
+var merkleTreeClassReference_ = &merkleTreeClass_{
+	// Initialize the class constants.
+}
@@ -0,0 +1,522 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	byt "bytes"
+	sig "crypto/ed25519"
+	ran "crypto/rand"
+	sha "crypto/sha1"
+	sh2 "crypto/sha256"
+	bin "encoding/binary"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	bit "math/bits"
+	tim "time"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func OpenPGPClass() OpenPGPClassLike {
+	return openPGPClass()
+}
+
+// Constructor Methods
+
+// Constant Methods
+
+// Function Methods
+
+func (c *openPGPClass_) SignatureMatches(
+	publicKey []byte,
+	bytes []byte,
+	signature []byte,
+) bool {
+	// Check for any errors at the end.
+	defer c.errorCheck(
+		"An error occurred while attempting to match an OpenPGP signature",
+	)
+
+	// The transferable public key begins with the public key packet.
+	var tag, keyBody, _ = c.readPacket(publicKey)
+	if tag != c.publicKeyTag_ {
+		panic("The public key does not begin with a public key packet.")
+	}
+	var version, key = c.parseKey(keyBody)
+	var fingerprint = c.fingerprint(keyBody)
+
+	// The detached signature is a single signature packet.
+	tag, body, rest := c.readPacket(signature)
+	if tag != c.signatureTag_ || len(rest) > 0 {
+		panic("The detached signature is not a single signature packet.")
+	}
+	if len(body) < 4 || uint(body[0]) != version {
+		return false
+	}
+	if body[1] != c.binaryDocument_ || body[2] != c.algorithm(version) || body[3] != c.sha256_ {
+		return false
+	}
+
+	// Separate the hashed portion of the signature from the rest of it.
+	var lengthSize = 2
+	if version == 6 {
+		lengthSize = 4
+	}
+	var hashedLength = c.readLength(body[4:], lengthSize)
+	var head = body[:4+lengthSize+hashedLength]
+	if !c.hasIssuer(head[4+lengthSize:], version, fingerprint) {
+		return false
+	}
+	var remainder = body[len(head):]
+	var unhashedLength = c.readLength(remainder, lengthSize)
+	remainder = remainder[lengthSize+unhashedLength:]
+	var left16 = remainder[:2]
+	remainder = remainder[2:]
+	var salt []byte
+	var value []byte
+	switch version {
+	case 4:
+		var r, s []byte
+		r, remainder = c.readMPI(remainder)
+		s, remainder = c.readMPI(remainder)
+		value = append(c.pad(r), c.pad(s)...)
+	case 6:
+		var size = int(remainder[0])
+		salt = remainder[1 : 1+size]
+		value = remainder[1+size:]
+		remainder = nil
+	}
+	if len(remainder) > 0 || len(value) != sig.SignatureSize {
+		return false
+	}
+
+	// Check the signature of the digest.
+	var digest = c.digest(salt, bytes, head)
+	if !byt.Equal(left16, digest[:2]) {
+		return false
+	}
+	return sig.Verify(key, digest, value)
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *openPGPClass_) algorithm(
+	version uint,
+) byte {
+	if version == 4 {
+		return c.eddsaLegacy_
+	}
+	return c.ed25519_
+}
+
+func (c *openPGPClass_) digest(
+	salt []byte,
+	data []byte,
+	head []byte,
+) []byte {
+	// The trailer identifies the version and the length of the hashed portion
+	// of the signature.
+	var trailer = []byte{head[0], 0xff}
+	trailer = bin.BigEndian.AppendUint32(trailer, uint32(len(head)))
+	var hash = sh2.New()
+	hash.Write(salt)
+	hash.Write(data)
+	hash.Write(head)
+	hash.Write(trailer)
+	return hash.Sum(nil)
+}
+
+func (c *openPGPClass_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"OpenPGP: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (c *openPGPClass_) exportKey(
+	certificate com.DocumentLike,
+	hsm Hardened,
+	userID string,
+	version uint,
+) []byte {
+	// Version 4 keys are certified using their user ID only, while version 6
+	// keys also require a direct key signature.
+	var keyBody = c.keyBody(certificate, hsm, version)
+	var fingerprint = c.fingerprint(keyBody)
+	var prefix = c.keyPrefix(keyBody)
+	var flags = c.subpacket(c.keyFlags_, []byte{0x03}) // Certify and sign.
+	var key = c.packet(c.publicKeyTag_, keyBody)
+	if version == 6 {
+		var signature = c.signatureBody(hsm, version, fingerprint, c.directKey_, prefix, flags)
+		key = append(key, c.packet(c.signatureTag_, signature)...)
+	}
+	var userIDPrefix = []byte{0xb4}
+	userIDPrefix = bin.BigEndian.AppendUint32(userIDPrefix, uint32(len(userID)))
+	userIDPrefix = append(userIDPrefix, userID...)
+	var signature = c.signatureBody(
+		hsm,
+		version,
+		fingerprint,
+		c.positiveCertification_,
+		append(prefix, userIDPrefix...),
+		flags,
+	)
+	key = append(key, c.packet(c.userIDTag_, []byte(userID))...)
+	return append(key, c.packet(c.signatureTag_, signature)...)
+}
+
+func (c *openPGPClass_) exportSignature(
+	certificate com.DocumentLike,
+	hsm Hardened,
+	bytes []byte,
+	version uint,
+) []byte {
+	var keyBody = c.keyBody(certificate, hsm, version)
+	var fingerprint = c.fingerprint(keyBody)
+	var signature = c.signatureBody(hsm, version, fingerprint, c.binaryDocument_, bytes, nil)
+	return c.packet(c.signatureTag_, signature)
+}
+
+func (c *openPGPClass_) fingerprint(
+	keyBody []byte,
+) []byte {
+	var prefix = c.keyPrefix(keyBody)
+	if keyBody[0] == 4 {
+		var hash = sha.Sum(prefix)
+		return hash[:]
+	}
+	var hash = sh2.Sum256(prefix)
+	return hash[:]
+}
+
+func (c *openPGPClass_) hasIssuer(
+	subpackets []byte,
+	version uint,
+	fingerprint []byte,
+) bool {
+	var issuer = append([]byte{byte(version)}, fingerprint...)
+	for len(subpackets) > 0 {
+		var size = int(subpackets[0]) // Only one octet lengths are generated.
+		if size == 0 || size >= 192 || size >= len(subpackets) {
+			return false
+		}
+		var type_ = subpackets[1] & 0x7f // Ignore the critical bit.
+		if type_ == c.issuerFingerprint_ && byt.Equal(subpackets[2:1+size], issuer) {
+			return true
+		}
+		subpackets = subpackets[1+size:]
+	}
+	return false
+}
+
+func (c *openPGPClass_) keyBody(
+	certificate com.DocumentLike,
+	hsm Hardened,
+	version uint,
+) []byte {
+	// Make sure the hardened module holds the key for the certificate.
+	if version != 4 && version != 6 {
+		var message = fmt.Sprintf(
+			"Only OpenPGP versions 4 and 6 are supported, not version %d.",
+			version,
+		)
+		panic(message)
+	}
+	var identity = com.IdentityClass().IdentityFromSource(
+		certificate.GetContent().AsSource(),
+	)
+	var key = identity.GetKey().AsIntrinsic()
+	if hsm.GetSignatureAlgorithm() != "ED25519" || !byt.Equal(key, hsm.GetPublicKey()) {
+		panic("The hardened module does not contain the Ed25519 key for the certificate.")
+	}
+
+	// The key was created when its certificate was notarized, so the key and
+	// its fingerprint remain the same each time they are exported.
+	var component = certificate.GetSubcomponent(
+		doc.Symbol("$notaries"),
+		-1, // The last notary seal.
+	)
+	var notary = com.NotaryClass().NotaryFromSource(doc.FormatComponent(component))
+	var created = uint32(notary.GetTimestamp().AsIntrinsic() / 1000)
+	var body = []byte{byte(version)}
+	body = bin.BigEndian.AppendUint32(body, created)
+	switch version {
+	case 4:
+		body = append(body, c.eddsaLegacy_, byte(len(c.curve_)))
+		body = append(body, c.curve_...)
+		body = append(body, c.mpi(append([]byte{0x40}, key...))...)
+	case 6:
+		body = append(body, c.ed25519_)
+		body = bin.BigEndian.AppendUint32(body, uint32(len(key)))
+		body = append(body, key...)
+	}
+	return body
+}
+
+func (c *openPGPClass_) keyPrefix(
+	keyBody []byte,
+) []byte {
+	// This prefix is used when hashing the key for fingerprints and signatures.
+	if keyBody[0] == 4 {
+		var prefix = []byte{0x99}
+		prefix = bin.BigEndian.AppendUint16(prefix, uint16(len(keyBody)))
+		return append(prefix, keyBody...)
+	}
+	var prefix = []byte{0x9b}
+	prefix = bin.BigEndian.AppendUint32(prefix, uint32(len(keyBody)))
+	return append(prefix, keyBody...)
+}
+
+func (c *openPGPClass_) mpi(
+	value []byte,
+) []byte {
+	value = byt.TrimLeft(value, "\x00")
+	var size = 0
+	if len(value) > 0 {
+		size = (len(value)-1)*8 + bit.Len8(value[0])
+	}
+	var result = bin.BigEndian.AppendUint16(nil, uint16(size))
+	return append(result, value...)
+}
+
+func (c *openPGPClass_) packet(
+	tag byte,
+	body []byte,
+) []byte {
+	// Packets are always generated using the OpenPGP packet format.
+	var header = []byte{0xc0 | tag}
+	var size = len(body)
+	switch {
+	case size < 192:
+		header = append(header, byte(size))
+	case size < 8384:
+		size -= 192
+		header = append(header, byte(size>>8)+192, byte(size))
+	default:
+		header = append(header, 0xff)
+		header = bin.BigEndian.AppendUint32(header, uint32(size))
+	}
+	return append(header, body...)
+}
+
+func (c *openPGPClass_) pad(
+	value []byte,
+) []byte {
+	if len(value) > 32 {
+		panic("An EdDSA signature value is longer than 32 bytes.")
+	}
+	return append(make([]byte, 32-len(value)), value...)
+}
+
+func (c *openPGPClass_) parseKey(
+	keyBody []byte,
+) (
+	version uint,
+	key []byte,
+) {
+	version = uint(keyBody[0])
+	switch {
+	case version == 4 && keyBody[5] == c.eddsaLegacy_:
+		var size = int(keyBody[6])
+		if !byt.Equal(keyBody[7:7+size], c.curve_) {
+			panic("The public key is not an Ed25519 key.")
+		}
+		var point, _ = c.readMPI(keyBody[7+size:])
+		if len(point) != 33 || point[0] != 0x40 {
+			panic("The public key contains an invalid Ed25519 point.")
+		}
+		key = point[1:]
+	case version == 6 && keyBody[5] == c.ed25519_:
+		key = keyBody[10:]
+	default:
+		panic("The public key is not a version 4 or 6 Ed25519 key.")
+	}
+	if len(key) != sig.PublicKeySize {
+		panic("The public key contains an invalid Ed25519 key.")
+	}
+	return
+}
+
+func (c *openPGPClass_) readLength(
+	bytes []byte,
+	size int,
+) int {
+	if size == 2 {
+		return int(bin.BigEndian.Uint16(bytes))
+	}
+	return int(bin.BigEndian.Uint32(bytes))
+}
+
+func (c *openPGPClass_) readMPI(
+	bytes []byte,
+) (
+	value []byte,
+	rest []byte,
+) {
+	var size = (int(bin.BigEndian.Uint16(bytes)) + 7) / 8
+	return bytes[2 : 2+size], bytes[2+size:]
+}
+
+func (c *openPGPClass_) readPacket(
+	bytes []byte,
+) (
+	tag byte,
+	body []byte,
+	rest []byte,
+) {
+	// Both the OpenPGP and the legacy packet formats are accepted.
+	var header = bytes[0]
+	if header&0x80 == 0 {
+		panic("An invalid OpenPGP packet header was found.")
+	}
+	var size int
+	var offset int
+	if header&0x40 != 0 {
+		tag = header & 0x3f
+		switch first := int(bytes[1]); {
+		case first < 192:
+			size, offset = first, 2
+		case first < 224:
+			size, offset = (first-192)<<8+int(bytes[2])+192, 3
+		case first == 255:
+			size, offset = int(bin.BigEndian.Uint32(bytes[2:])), 6
+		default:
+			panic("Partial OpenPGP packet lengths are not supported.")
+		}
+	} else {
+		tag = (header >> 2) & 0x0f
+		switch header & 0x03 {
+		case 0:
+			size, offset = int(bytes[1]), 2
+		case 1:
+			size, offset = int(bin.BigEndian.Uint16(bytes[1:])), 3
+		case 2:
+			size, offset = int(bin.BigEndian.Uint32(bytes[1:])), 5
+		default:
+			panic("Indeterminate OpenPGP packet lengths are not supported.")
+		}
+	}
+	return tag, bytes[offset : offset+size], bytes[offset+size:]
+}
+
+func (c *openPGPClass_) signatureBody(
+	hsm Hardened,
+	version uint,
+	fingerprint []byte,
+	type_ byte,
+	data []byte,
+	subpackets []byte,
+) []byte {
+	// The hashed subpackets identify when and by which key the signature was
+	// created.
+	var created = bin.BigEndian.AppendUint32(nil, uint32(tim.Now().Unix()))
+	var issuer = append([]byte{byte(version)}, fingerprint...)
+	var hashed = c.subpacket(c.creationTime_, created)
+	hashed = append(hashed, c.subpacket(c.issuerFingerprint_, issuer)...)
+	hashed = append(hashed, subpackets...)
+	var head = []byte{byte(version), type_, c.algorithm(version), c.sha256_}
+
+	// Each version lays out the rest of the signature differently.
+	var body []byte
+	switch version {
+	case 4:
+		head = bin.BigEndian.AppendUint16(head, uint16(len(hashed)))
+		head = append(head, hashed...)
+		var digest = c.digest(nil, data, head)
+		var signature = hsm.SignBytes(digest)
+		var unhashed = c.subpacket(c.issuer_, fingerprint[12:])
+		body = bin.BigEndian.AppendUint16(head, uint16(len(unhashed)))
+		body = append(body, unhashed...)
+		body = append(body, digest[:2]...)
+		body = append(body, c.mpi(signature[:32])...)
+		body = append(body, c.mpi(signature[32:])...)
+	case 6:
+		head = bin.BigEndian.AppendUint32(head, uint32(len(hashed)))
+		head = append(head, hashed...)
+		var salt = make([]byte, 16) // The salt size for SHA256.
+		if _, err := ran.Read(salt); err != nil {
+			panic(err)
+		}
+		var digest = c.digest(salt, data, head)
+		var signature = hsm.SignBytes(digest)
+		body = bin.BigEndian.AppendUint32(head, 0) // No unhashed subpackets.
+		body = append(body, digest[:2]...)
+		body = append(body, byte(len(salt)))
+		body = append(body, salt...)
+		body = append(body, signature...)
+	}
+	return body
+}
+
+func (c *openPGPClass_) subpacket(
+	type_ byte,
+	data []byte,
+) []byte {
+	var result = []byte{byte(len(data) + 1), type_}
+	return append(result, data...)
+}
+
+// Class Structure
+
+type openPGPClass_ struct {
+	// Declare the class constants.
+	signatureTag_          byte
+	publicKeyTag_          byte
+	userIDTag_             byte
+	binaryDocument_        byte
+	positiveCertification_ byte
+	directKey_             byte
+	eddsaLegacy_           byte
+	ed25519_               byte
+	sha256_                byte
+	creationTime_          byte
+	issuer_                byte
+	keyFlags_              byte
+	issuerFingerprint_     byte
+	curve_                 []byte
+}
+
+// Class Reference
+
+func openPGPClass() *openPGPClass_ {
+	return openPGPClassReference_
+}
+
+var openPGPClassReference_ = &openPGPClass_{
+	// Initialize the class constants.
+	signatureTag_:          2,
+	publicKeyTag_:          6,
+	userIDTag_:             13,
+	binaryDocument_:        0x00,
+	positiveCertification_: 0x13,
+	directKey_:             0x1f,
+	eddsaLegacy_:           22,
+	ed25519_:               27,
+	sha256_:                8,
+	creationTime_:          2,
+	issuer_:                16,
+	keyFlags_:              27,
+	issuerFingerprint_:     33,
+	curve_:                 []byte{0x2b, 0x06, 0x01, 0x04, 0x01, 0xda, 0x47, 0x0f, 0x01},
+}
@@ -0,0 +1,664 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	byt "bytes"
+	ran "crypto/rand"
+	dig "crypto/sha512"
+	bin "encoding/binary"
+	ed2 "filippo.io/edwards25519"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	stc "strconv"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func ParticipantEd25519Class() ParticipantEd25519ClassLike {
+	return participantEd25519Class()
+}
+
+// Constructor Methods
+
+func (c *participantEd25519Class_) ParticipantEd25519(
+	directory string,
+	identifier uint,
+) ParticipantEd25519Like {
+	if uti.IsUndefined(directory) {
+		panic("The \"directory\" attribute is required by this class.")
+	}
+	if identifier == 0 || identifier > 255 {
+		panic("The \"identifier\" attribute must be in the range [1..255].")
+	}
+	uti.MakeDirectory(directory)
+	var filename = directory + "/Participant" + stc.Itoa(int(identifier)) + ".bali"
+	var instance = &participantEd25519_{
+		// Initialize the instance attributes.
+		identifier_: identifier,
+		filename_:   filename,
+	}
+	if uti.PathExists(filename) {
+		instance.readConfiguration()
+	} else {
+		instance.writeConfiguration()
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *participantEd25519_) GetClass() ParticipantEd25519ClassLike {
+	return participantEd25519Class()
+}
+
+// Attribute Methods
+
+// Participating Methods
+
+func (v *participantEd25519_) GetIdentifier() uint {
+	return v.identifier_
+}
+
+func (v *participantEd25519_) GetGroupKey() []byte {
+	return v.groupKey_
+}
+
+func (v *participantEd25519_) GetPreviousGroupKey() []byte {
+	return v.previousGroupKey_
+}
+
+func (v *participantEd25519_) GenerateCommitments(
+	threshold uint,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to generate key commitments",
+	)
+
+	// Generate a random polynomial of degree threshold - 1.
+	if threshold < 2 {
+		panic("The threshold must be at least two.")
+	}
+	v.coefficients_ = make([]*ed2.Scalar, threshold)
+	for index := range v.coefficients_ {
+		v.coefficients_[index] = v.randomScalar()
+	}
+
+	// Prove knowledge of the constant term to prevent rogue key attacks.
+	var constant = ed2.NewIdentityPoint().ScalarBaseMult(v.coefficients_[0])
+	var nonce = v.randomScalar()
+	var commitment = ed2.NewIdentityPoint().ScalarBaseMult(nonce)
+	var challenge = v.proofChallenge(v.identifier_, constant, commitment)
+	var proof = ed2.NewScalar().MultiplyAdd(v.coefficients_[0], challenge, nonce)
+
+	// Package up the identifier, proof and commitments to the coefficients.
+	var bytes = v.encodeIdentifier(v.identifier_)
+	bytes = append(bytes, commitment.Bytes()...)
+	bytes = append(bytes, proof.Bytes()...)
+	for _, coefficient := range v.coefficients_ {
+		var point = ed2.NewIdentityPoint().ScalarBaseMult(coefficient)
+		bytes = append(bytes, point.Bytes()...)
+	}
+	return bytes
+}
+
+func (v *participantEd25519_) GenerateShare(
+	recipient uint,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to generate a key share",
+	)
+
+	if v.coefficients_ == nil {
+		panic("No key generation is in progress.")
+	}
+	var x = v.identifierScalar(recipient)
+	return v.evaluate(v.coefficients_, x).Bytes()
+}
+
+func (v *participantEd25519_) CompleteKeys(
+	packages [][]byte,
+	shares [][]byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to complete the key generation",
+	)
+
+	if v.coefficients_ == nil {
+		panic("No key generation is in progress.")
+	}
+	if len(packages) != len(shares) {
+		panic("The number of packages and shares must be the same.")
+	}
+	var threshold = len(v.coefficients_)
+	if len(packages) < threshold {
+		panic("There are fewer key packages than the threshold.")
+	}
+	var senders = make(map[uint]bool, len(packages))
+	var x = v.identifierScalar(v.identifier_)
+	var share = ed2.NewScalar()
+	var groupKey = ed2.NewIdentityPoint()
+	for index, bytes := range packages {
+		// Parse the package from the sending participant.
+		if len(bytes) != 96+32*threshold {
+			panic("A key package has an invalid length.")
+		}
+		var sender = uint(bin.LittleEndian.Uint32(bytes[:4]))
+		if sender == 0 {
+			panic("A key package is missing its sender identifier.")
+		}
+		if senders[sender] {
+			var message = fmt.Sprintf(
+				"Participant %d sent more than one key package.",
+				sender,
+			)
+			panic(message)
+		}
+		senders[sender] = true
+		var commitment = v.decodePoint(bytes[32:64])
+		var proof = v.decodeScalar(bytes[64:96])
+		var points = make([]*ed2.Point, threshold)
+		for k := range points {
+			points[k] = v.decodePoint(bytes[96+32*k : 128+32*k])
+		}
+
+		// Verify the proof of knowledge of the constant term.
+		var challenge = v.proofChallenge(sender, points[0], commitment)
+		var expected = ed2.NewIdentityPoint().VarTimeDoubleScalarBaseMult(
+			ed2.NewScalar().Negate(challenge),
+			points[0],
+			proof,
+		)
+		if expected.Equal(commitment) != 1 {
+			var message = fmt.Sprintf(
+				"Participant %d provided an invalid proof of knowledge.",
+				sender,
+			)
+			panic(message)
+		}
+
+		// Verify the share against the commitments to the coefficients.
+		var value = v.decodeScalar(shares[index])
+		var power = ed2.NewScalar().Set(v.scalarOne())
+		var sum = ed2.NewIdentityPoint()
+		for _, point := range points {
+			sum.Add(sum, ed2.NewIdentityPoint().ScalarMult(power, point))
+			power.Multiply(power, x)
+		}
+		if ed2.NewIdentityPoint().ScalarBaseMult(value).Equal(sum) != 1 {
+			var message = fmt.Sprintf(
+				"Participant %d provided an invalid key share.",
+				sender,
+			)
+			panic(message)
+		}
+		share.Add(share, value)
+		groupKey.Add(groupKey, points[0])
+	}
+
+	// The key packages must include the one from this participant.
+	if !senders[v.identifier_] {
+		panic("The key packages do not include one from this participant.")
+	}
+
+	// Retain any current key as the previous key during a rotation, which
+	// replaces the record of any earlier rotation.
+	v.coefficients_ = nil
+	v.previousGroupKey_ = v.groupKey_
+	v.previousShare_ = v.share_
+	v.groupKey_ = groupKey.Bytes()
+	v.share_ = share.Bytes()
+	v.record_ = nil
+	v.signature_ = nil
+	v.writeConfiguration()
+	return v.groupKey_
+}
+
+func (v *participantEd25519_) CommitNonces() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to commit to signing nonces",
+	)
+
+	if v.share_ == nil {
+		panic("The participant does not have a key share.")
+	}
+
+	// Generate the hiding and binding nonces as described in RFC 9591.
+	v.hiding_ = v.generateNonce(v.share_)
+	v.binding_ = v.generateNonce(v.share_)
+	var hiding = ed2.NewIdentityPoint().ScalarBaseMult(v.hiding_)
+	var binding = ed2.NewIdentityPoint().ScalarBaseMult(v.binding_)
+	var bytes = v.encodeIdentifier(v.identifier_)
+	bytes = append(bytes, hiding.Bytes()...)
+	bytes = append(bytes, binding.Bytes()...)
+	return bytes
+}
+
+func (v *participantEd25519_) SignShare(
+	message []byte,
+	commitments [][]byte,
+	previous bool,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to sign a message share",
+	)
+
+	// Each pair of nonces may only be used once.
+	if v.hiding_ == nil {
+		panic("The participant has not committed to any signing nonces.")
+	}
+	var hiding = v.hiding_
+	var binding = v.binding_
+	v.hiding_ = nil
+	v.binding_ = nil
+
+	// Select the key share.
+	var groupKey = v.groupKey_
+	var secret = v.share_
+	if previous {
+		groupKey = v.previousGroupKey_
+		secret = v.previousShare_
+	}
+	if secret == nil {
+		panic("The participant does not have the requested key share.")
+	}
+
+	// Compute the binding factors and the group commitment.
+	var identifiers = make([]*ed2.Scalar, len(commitments))
+	var commitmentList []byte
+	var included bool
+	var last uint
+	for index, commitment := range commitments {
+		if len(commitment) != 96 {
+			panic("A signing commitment has an invalid length.")
+		}
+
+		// The commitments must be ordered by identifier without duplicates.
+		var identifier = uint(bin.LittleEndian.Uint32(commitment[:4]))
+		if identifier <= last {
+			panic("The signing commitments are not ordered by unique identifiers.")
+		}
+		last = identifier
+		identifiers[index] = v.decodeScalar(commitment[:32])
+		commitmentList = append(commitmentList, commitment...)
+
+		// The commitment from this participant must be to its own nonces.
+		if identifier == v.identifier_ {
+			var hidingPoint = ed2.NewIdentityPoint().ScalarBaseMult(hiding)
+			var bindingPoint = ed2.NewIdentityPoint().ScalarBaseMult(binding)
+			if !byt.Equal(commitment[32:64], hidingPoint.Bytes()) ||
+				!byt.Equal(commitment[64:96], bindingPoint.Bytes()) {
+				panic("The signing commitment for the participant does not match its nonces.")
+			}
+			included = true
+		}
+	}
+	if !included {
+		panic("The participant is not included in the signing commitments.")
+	}
+	var prefix = append([]byte{}, groupKey...)
+	prefix = append(prefix, v.digestBytes("msg", message)...)
+	prefix = append(prefix, v.digestBytes("com", commitmentList)...)
+	var self = v.identifierScalar(v.identifier_)
+	var factor *ed2.Scalar
+	var group = ed2.NewIdentityPoint()
+	for index, commitment := range commitments {
+		var rho = v.hashBytes("rho", append(append([]byte{}, prefix...), commitment[:32]...))
+		if identifiers[index].Equal(self) == 1 {
+			factor = rho
+		}
+		var hidingPoint = v.decodePoint(commitment[32:64])
+		var bindingPoint = v.decodePoint(commitment[64:96])
+		group.Add(group, hidingPoint)
+		group.Add(group, ed2.NewIdentityPoint().ScalarMult(rho, bindingPoint))
+	}
+
+	// Compute the Ed25519 challenge and the Lagrange coefficient.
+	var digest = dig.New()
+	digest.Write(group.Bytes())
+	digest.Write(groupKey)
+	digest.Write(message)
+	var challenge, _ = ed2.NewScalar().SetUniformBytes(digest.Sum(nil))
+	var lambda = ed2.NewScalar().Set(v.scalarOne())
+	for _, identifier := range identifiers {
+		if identifier.Equal(self) != 1 {
+			var denominator = ed2.NewScalar().Subtract(identifier, self)
+			lambda.Multiply(lambda, identifier)
+			lambda.Multiply(lambda, ed2.NewScalar().Invert(denominator))
+		}
+	}
+
+	// Compute the signature share.
+	var z = ed2.NewScalar().MultiplyAdd(binding, factor, hiding)
+	var weight = ed2.NewScalar().Multiply(lambda, v.decodeScalar(secret))
+	z.MultiplyAdd(weight, challenge, z)
+
+	return append(group.Bytes(), z.Bytes()...)
+}
+
+func (v *participantEd25519_) RecordRotation(
+	record []byte,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to record a key rotation",
+	)
+
+	if v.previousShare_ == nil {
+		panic("The participant does not have a pending key rotation.")
+	}
+	v.record_ = record
+	v.writeConfiguration()
+}
+
+func (v *participantEd25519_) GetRotationRecord() []byte {
+	return v.record_
+}
+
+func (v *participantEd25519_) DiscardPreviousKey(
+	signature []byte,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to discard the previous key share",
+	)
+
+	// The signature is recorded in the same write that discards the share.
+	v.previousGroupKey_ = nil
+	v.previousShare_ = nil
+	v.signature_ = signature
+	v.writeConfiguration()
+}
+
+func (v *participantEd25519_) GetRotationSignature() []byte {
+	return v.signature_
+}
+
+func (v *participantEd25519_) RestorePreviousKey() {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to restore the previous key share",
+	)
+
+	if v.previousShare_ == nil {
+		panic("The participant does not have a previous key share.")
+	}
+	v.groupKey_ = v.previousGroupKey_
+	v.share_ = v.previousShare_
+	v.previousGroupKey_ = nil
+	v.previousShare_ = nil
+	v.record_ = nil
+	v.signature_ = nil
+	v.writeConfiguration()
+}
+
+func (v *participantEd25519_) EraseShares() {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to erase the key shares",
+	)
+
+	v.coefficients_ = nil
+	v.hiding_ = nil
+	v.binding_ = nil
+	v.groupKey_ = nil
+	v.share_ = nil
+	v.previousGroupKey_ = nil
+	v.previousShare_ = nil
+	v.record_ = nil
+	v.signature_ = nil
+	v.writeConfiguration()
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *participantEd25519_) decodePoint(
+	bytes []byte,
+) *ed2.Point {
+	var point, err = ed2.NewIdentityPoint().SetBytes(bytes)
+	if err != nil {
+		panic(err)
+	}
+	return point
+}
+
+func (v *participantEd25519_) decodeScalar(
+	bytes []byte,
+) *ed2.Scalar {
+	var scalar, err = ed2.NewScalar().SetCanonicalBytes(bytes)
+	if err != nil {
+		panic(err)
+	}
+	return scalar
+}
+
+func (v *participantEd25519_) encodeIdentifier(
+	identifier uint,
+) []byte {
+	var bytes = make([]byte, 32)
+	bin.LittleEndian.PutUint32(bytes, uint32(identifier))
+	return bytes
+}
+
+func (v *participantEd25519_) digestBytes(
+	label string,
+	bytes []byte,
+) []byte {
+	var digest = dig.New()
+	digest.Write([]byte(participantEd25519Class().context_ + label))
+	digest.Write(bytes)
+	return digest.Sum(nil)
+}
+
+func (v *participantEd25519_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"ParticipantEd25519: %s:\n        %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *participantEd25519_) evaluate(
+	coefficients []*ed2.Scalar,
+	x *ed2.Scalar,
+) *ed2.Scalar {
+	// Use Horner's method to evaluate the polynomial.
+	var y = ed2.NewScalar()
+	for index := len(coefficients) - 1; index >= 0; index-- {
+		y.MultiplyAdd(y, x, coefficients[index])
+	}
+	return y
+}
+
+func (v *participantEd25519_) generateNonce(
+	secret []byte,
+) *ed2.Scalar {
+	var random = make([]byte, 32)
+	var _, err = ran.Read(random)
+	if err != nil {
+		panic(err)
+	}
+	return v.hashBytes("nonce", append(random, secret...))
+}
+
+func (v *participantEd25519_) hashBytes(
+	label string,
+	bytes []byte,
+) *ed2.Scalar {
+	var scalar, _ = ed2.NewScalar().SetUniformBytes(v.digestBytes(label, bytes))
+	return scalar
+}
+
+func (v *participantEd25519_) identifierScalar(
+	identifier uint,
+) *ed2.Scalar {
+	return v.decodeScalar(v.encodeIdentifier(identifier))
+}
+
+func (v *participantEd25519_) proofChallenge(
+	identifier uint,
+	constant *ed2.Point,
+	commitment *ed2.Point,
+) *ed2.Scalar {
+	var bytes = v.encodeIdentifier(identifier)
+	bytes = append(bytes, constant.Bytes()...)
+	bytes = append(bytes, commitment.Bytes()...)
+	return v.hashBytes("dkg", bytes)
+}
+
+func (v *participantEd25519_) randomScalar() *ed2.Scalar {
+	var bytes = make([]byte, 64)
+	var _, err = ran.Read(bytes)
+	if err != nil {
+		panic(err)
+	}
+	var scalar, _ = ed2.NewScalar().SetUniformBytes(bytes)
+	return scalar
+}
+
+func (v *participantEd25519_) readConfiguration() {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to read in the participant configuration",
+	)
+
+	var source = uti.ReadFile(v.filename_)
+	var component = doc.ParseComponent(source)
+	var identifier = doc.FormatComponent(
+		component.GetSubcomponent(doc.Symbol("$identifier")),
+	)
+	if identifier != stc.Itoa(int(v.identifier_)) {
+		panic("The specified identifier does not match the participant identifier.")
+	}
+	v.groupKey_ = v.readBinary(component, "$groupKey")
+	v.share_ = v.readBinary(component, "$share")
+	v.previousGroupKey_ = v.readBinary(component, "$previousGroupKey")
+	v.previousShare_ = v.readBinary(component, "$previousShare")
+	v.record_ = v.readBinary(component, "$rotationRecord")
+	v.signature_ = v.readBinary(component, "$rotationSignature")
+}
+
+func (v *participantEd25519_) readBinary(
+	component doc.Composite,
+	symbol string,
+) []byte {
+	// Attributes added by later versions may be missing from older files.
+	var bytes []byte
+	var subcomponent = component.GetSubcomponent(doc.Symbol(symbol))
+	if uti.IsUndefined(subcomponent) {
+		return bytes
+	}
+	var source = doc.FormatComponent(subcomponent)
+	if source != "none" {
+		bytes = doc.Binary(source).AsIntrinsic()
+	}
+	return bytes
+}
+
+func (v *participantEd25519_) scalarOne() *ed2.Scalar {
+	var bytes = make([]byte, 32)
+	bytes[0] = 1
+	return v.decodeScalar(bytes)
+}
+
+func (v *participantEd25519_) writeBinary(
+	bytes []byte,
+) string {
+	var source = "none"
+	if uti.IsDefined(bytes) {
+		source = doc.Binary(bytes).AsSource()
+	}
+	return source
+}
+
+func (v *participantEd25519_) writeConfiguration() {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to write out the participant configuration",
+	)
+
+	var source = `[
+    $identifier: ` + stc.Itoa(int(v.identifier_)) + `
+    $groupKey: ` + v.writeBinary(v.groupKey_) + `
+    $share: ` + v.writeBinary(v.share_) + `
+    $previousGroupKey: ` + v.writeBinary(v.previousGroupKey_) + `
+    $previousShare: ` + v.writeBinary(v.previousShare_) + `
+    $rotationRecord: ` + v.writeBinary(v.record_) + `
+    $rotationSignature: ` + v.writeBinary(v.signature_) + `
+](
+    $type: /bali/types/notary/ParticipantEd25519/v3
+)
+`
+
+	// Write the journal first and then atomically replace the configuration
+	// file so that a crash never leaves a partially written configuration.
+	var journal = v.filename_ + ".journal"
+	uti.WriteFile(journal, source)
+	uti.RenamePath(journal, v.filename_)
+}
+
+// Instance Structure
+
+type participantEd25519_ struct {
+	// Declare the instance attributes.
+	identifier_       uint
+	filename_         string
+	groupKey_         []byte
+	share_            []byte
+	previousGroupKey_ []byte
+	previousShare_    []byte
+	record_           []byte
+	signature_        []byte
+	coefficients_     []*ed2.Scalar
+	hiding_           *ed2.Scalar
+	binding_          *ed2.Scalar
+}
+
+// Class Structure
+
+type participantEd25519Class_ struct {
+	// Declare the class constants.
+	context_ string
+}
+
+// Class Reference
+
+func participantEd25519Class() *participantEd25519Class_ {
+	return participantEd25519ClassReference_
+}
+
+var participantEd25519ClassReference_ = &participantEd25519Class_{
+	// Initialize the class constants.
+	context_: "FROST-ED25519-SHA512-v1",
+}
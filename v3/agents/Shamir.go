@@ -0,0 +1,204 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	ran "crypto/rand"
+	fmt "fmt"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func ShamirClass() ShamirClassLike {
+	return shamirClass()
+}
+
+// Constructor Methods
+
+// Constant Methods
+
+// Function Methods
+
+func (c *shamirClass_) SplitSecret(
+	secret []byte,
+	threshold uint,
+	count uint,
+) [][]byte {
+	// Check for any errors at the end.
+	defer c.errorCheck(
+		"An error occurred while attempting to split a secret",
+	)
+
+	// Validate the arguments.
+	if len(secret) == 0 {
+		panic("The secret must not be empty.")
+	}
+	if threshold < 2 || threshold > count || count > 255 {
+		var message = fmt.Sprintf(
+			"An invalid threshold (%d) and count (%d) were specified.",
+			threshold,
+			count,
+		)
+		panic(message)
+	}
+
+	// Each share is its x-coordinate followed by one y-coordinate per byte.
+	var shares = make([][]byte, count)
+	for index := range shares {
+		shares[index] = make([]byte, len(secret)+1)
+		shares[index][0] = byte(index + 1)
+	}
+
+	// Split each byte of the secret using a random polynomial whose constant
+	// term is the secret byte.
+	var coefficients = make([]byte, threshold)
+	for position, value := range secret {
+		coefficients[0] = value
+		var _, err = ran.Read(coefficients[1:])
+		if err != nil {
+			panic(err)
+		}
+		for _, share := range shares {
+			share[position+1] = c.evaluate(coefficients, share[0])
+		}
+	}
+	return shares
+}
+
+func (c *shamirClass_) CombineShares(
+	shares [][]byte,
+) []byte {
+	// Check for any errors at the end.
+	defer c.errorCheck(
+		"An error occurred while attempting to combine secret shares",
+	)
+
+	// Validate the shares.
+	if len(shares) < 2 {
+		panic("At least two shares are required to recover a secret.")
+	}
+	var size = len(shares[0])
+	var seen = make(map[byte]bool)
+	for _, share := range shares {
+		if len(share) != size || size < 2 {
+			panic("The shares have inconsistent lengths.")
+		}
+		if share[0] == 0 || seen[share[0]] {
+			panic("The shares have invalid or duplicate indices.")
+		}
+		seen[share[0]] = true
+	}
+
+	// Use Lagrange interpolation at zero to recover each byte of the secret.
+	var secret = make([]byte, size-1)
+	for position := range secret {
+		var value byte
+		for i, share := range shares {
+			var numerator byte = 1
+			var denominator byte = 1
+			for j, other := range shares {
+				if i != j {
+					numerator = c.multiply(numerator, other[0])
+					denominator = c.multiply(denominator, share[0]^other[0])
+				}
+			}
+			var basis = c.divide(numerator, denominator)
+			value ^= c.multiply(share[position+1], basis)
+		}
+		secret[position] = value
+	}
+	return secret
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *shamirClass_) divide(
+	a byte,
+	b byte,
+) byte {
+	if a == 0 {
+		return 0
+	}
+	var difference = int(c.logarithms_[a]) - int(c.logarithms_[b])
+	if difference < 0 {
+		difference += 255
+	}
+	return c.exponents_[difference]
+}
+
+func (c *shamirClass_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"Shamir: %s:\n        %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (c *shamirClass_) evaluate(
+	coefficients []byte,
+	x byte,
+) byte {
+	// Use Horner's method to evaluate the polynomial in GF(256).
+	var y byte
+	for index := len(coefficients) - 1; index >= 0; index-- {
+		y = c.multiply(y, x) ^ coefficients[index]
+	}
+	return y
+}
+
+func (c *shamirClass_) multiply(
+	a byte,
+	b byte,
+) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	var sum = int(c.logarithms_[a]) + int(c.logarithms_[b])
+	return c.exponents_[sum%255]
+}
+
+// Class Structure
+
+type shamirClass_ struct {
+	// Declare the class constants.
+	exponents_  [255]byte
+	logarithms_ [256]byte
+}
+
+// Class Reference
+
+func shamirClass() *shamirClass_ {
+	return shamirClassReference_
+}
+
+var shamirClassReference_ = func() *shamirClass_ {
+	// Initialize the class constants using the generator 0x03 for the AES
+	// field polynomial x^8 + x^4 + x^3 + x + 1.
+	var class = &shamirClass_{}
+	var value byte = 1
+	for power := 0; power < 255; power++ {
+		class.exponents_[power] = value
+		class.logarithms_[value] = byte(power)
+		value ^= value<<1 ^ byte(int8(value)>>7)&0x1b
+	}
+	return class
+}()
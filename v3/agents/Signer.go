@@ -0,0 +1,115 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	cry "crypto"
+	sig "crypto/ed25519"
+	fmt "fmt"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	iox "io"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func SignerClass() SignerClassLike {
+	return signerClass()
+}
+
+// Constructor Methods
+
+func (c *signerClass_) Signer(
+	hsm Hardened,
+) SignerLike {
+	if uti.IsUndefined(hsm) {
+		panic("The \"hsm\" attribute is required by this class.")
+	}
+	var algorithm = hsm.GetSignatureAlgorithm()
+	if algorithm != "ED25519" {
+		var message = fmt.Sprintf(
+			"A signer cannot be created for a %s key.",
+			algorithm,
+		)
+		panic(message)
+	}
+	var instance = &signer_{
+		// Initialize the instance attributes.
+		hsm_: hsm,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *signer_) GetClass() SignerClassLike {
+	return signerClass()
+}
+
+// Attribute Methods
+
+func (v *signer_) GetHsm() Hardened {
+	return v.hsm_
+}
+
+// Signer Methods
+
+func (v *signer_) Public() cry.PublicKey {
+	return sig.PublicKey(v.hsm_.GetPublicKey())
+}
+
+func (v *signer_) Sign(
+	random iox.Reader,
+	message []byte,
+	options cry.SignerOpts,
+) ([]byte, error) {
+	// Ed25519 signs the whole message so no hash function is ever requested.
+	if options.HashFunc() != cry.Hash(0) {
+		return nil, fmt.Errorf("Ed25519 keys do not sign pre-hashed messages.")
+	}
+	return v.hsm_.SignBytes(message), nil
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+// Instance Structure
+
+type signer_ struct {
+	// Declare the instance attributes.
+	hsm_ Hardened
+}
+
+// Class Structure
+
+type signerClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func signerClass() *signerClass_ {
+	return signerClassReference_
+}
+
+var signerClassReference_ = &signerClass_{
+	// Initialize the class constants.
+}
@@ -0,0 +1,109 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	dig "crypto/sha3"
+	fmt "fmt"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func SsmSha3Class() SsmSha3ClassLike {
+	return ssmSha3Class()
+}
+
+// Constructor Methods
+
+func (c *ssmSha3Class_) SsmSha3() SsmSha3Like {
+	var instance = &ssmSha3_{
+		// Initialize the instance attributes.
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *ssmSha3_) GetClass() SsmSha3ClassLike {
+	return ssmSha3Class()
+}
+
+// Attribute Methods
+
+// Trusted Methods
+
+func (v *ssmSha3_) GetDigestAlgorithm() string {
+	return ssmSha3Class().algorithm_
+}
+
+func (v *ssmSha3_) DigestBytes(
+	bytes []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to digest bytes",
+	)
+
+	var array = dig.Sum512(bytes)
+	var digest = array[:] // Convert the [64]byte array to a slice.
+	return digest
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *ssmSha3_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"SsmSha3: %s:\n        %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+// Instance Structure
+
+type ssmSha3_ struct {
+	// Declare the instance attributes.
+}
+
+// Class Structure
+
+type ssmSha3Class_ struct {
+	// Declare the class constants.
+	algorithm_ string
+}
+
+// Class Reference
+
+func ssmSha3Class() *ssmSha3Class_ {
+	return ssmSha3ClassReference_
+}
+
+var ssmSha3ClassReference_ = &ssmSha3Class_{
+	// Initialize the class constants.
+	algorithm_: "SHA3-512",
+}
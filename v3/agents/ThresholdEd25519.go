@@ -0,0 +1,434 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	byt "bytes"
+	cmp "cmp"
+	sig "crypto/ed25519"
+	ed2 "filippo.io/edwards25519"
+	fmt "fmt"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	slc "slices"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func ThresholdEd25519Class() ThresholdEd25519ClassLike {
+	return thresholdEd25519Class()
+}
+
+// Constructor Methods
+
+func (c *thresholdEd25519Class_) ThresholdEd25519(
+	threshold uint,
+	participants []Participating,
+) ThresholdEd25519Like {
+	if threshold < 2 || int(threshold) > len(participants) {
+		panic("The \"threshold\" attribute must be in the range [2..len(participants)].")
+	}
+	var controller = uti.Controller(c.events_, c.transitions_, c.keyless_)
+	var instance = &thresholdEd25519_{
+		// Initialize the instance attributes.
+		threshold_:    threshold,
+		participants_: participants,
+		controller_:   controller,
+	}
+
+	// The first threshold participants sign until other signers are chosen.
+	var signers = make([]uint, threshold)
+	for index := range signers {
+		signers[index] = participants[index].GetIdentifier()
+	}
+	instance.SetSigners(signers)
+
+	// Determine the current state from the participants.
+	switch {
+	case uti.IsDefined(instance.GetPreviousKey()):
+		controller.SetState(c.twoKeys_)
+	case uti.IsDefined(instance.GetPublicKey()):
+		controller.SetState(c.loneKey_)
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *thresholdEd25519_) GetClass() ThresholdEd25519ClassLike {
+	return thresholdEd25519Class()
+}
+
+// Attribute Methods
+
+func (v *thresholdEd25519_) GetThreshold() uint {
+	return v.threshold_
+}
+
+func (v *thresholdEd25519_) GetParticipants() []Participating {
+	return v.participants_
+}
+
+func (v *thresholdEd25519_) GetSigners() []uint {
+	var identifiers = make([]uint, len(v.signers_))
+	for index, signer := range v.signers_ {
+		identifiers[index] = signer.GetIdentifier()
+	}
+	return identifiers
+}
+
+func (v *thresholdEd25519_) SetSigners(
+	identifiers []uint,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to choose the signers",
+	)
+
+	if len(identifiers) < int(v.threshold_) {
+		panic("At least a threshold number of signers is required.")
+	}
+	var signers = make([]Participating, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		var participant = v.participant(identifier)
+		if uti.IsUndefined(participant) {
+			var message = fmt.Sprintf(
+				"The signer %d is not a participant.",
+				identifier,
+			)
+			panic(message)
+		}
+		for _, signer := range signers {
+			if signer.GetIdentifier() == identifier {
+				var message = fmt.Sprintf(
+					"The signer %d was chosen more than once.",
+					identifier,
+				)
+				panic(message)
+			}
+		}
+		signers = append(signers, participant)
+	}
+
+	// The signing commitments must be ordered by participant identifier.
+	slc.SortFunc(signers, func(first, second Participating) int {
+		return cmp.Compare(first.GetIdentifier(), second.GetIdentifier())
+	})
+	v.signers_ = signers
+}
+
+// Hardened Methods
+
+func (v *thresholdEd25519_) GetSignatureAlgorithm() string {
+	return thresholdEd25519Class().algorithm_
+}
+
+func (v *thresholdEd25519_) GetPublicKey() []byte {
+	return v.agreedBytes("group key", Participating.GetGroupKey)
+}
+
+func (v *thresholdEd25519_) GenerateKeys() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to generate new keys",
+	)
+
+	v.controller_.ProcessEvent(thresholdEd25519Class().generateKeys_)
+	return v.generateKeys()
+}
+
+func (v *thresholdEd25519_) SignBytes(
+	bytes []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to sign bytes",
+	)
+
+	v.controller_.ProcessEvent(thresholdEd25519Class().signBytes_)
+	return v.signBytes(bytes, false)
+}
+
+func (v *thresholdEd25519_) IsValid(
+	key []byte,
+	bytes []byte,
+	signature []byte,
+) bool {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to verify bytes signature",
+	)
+
+	return sig.Verify(sig.PublicKey(key), bytes, signature)
+}
+
+func (v *thresholdEd25519_) RotateKeys() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to rotate keys",
+	)
+
+	v.controller_.ProcessEvent(thresholdEd25519Class().rotateKeys_)
+	return v.generateKeys()
+}
+
+func (v *thresholdEd25519_) GetPreviousKey() []byte {
+	return v.agreedBytes("previous group key", Participating.GetPreviousGroupKey)
+}
+
+func (v *thresholdEd25519_) RecordRotation(
+	record []byte,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to record a key rotation",
+	)
+
+	if v.controller_.GetState() != thresholdEd25519Class().twoKeys_ {
+		panic("A key rotation is not pending.")
+	}
+	for _, participant := range v.participants_ {
+		participant.RecordRotation(record)
+	}
+}
+
+func (v *thresholdEd25519_) GetRotationRecord() []byte {
+	return v.agreedBytes("rotation record", Participating.GetRotationRecord)
+}
+
+func (v *thresholdEd25519_) SignWithPreviousKey(
+	bytes []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to sign bytes with the previous key",
+	)
+
+	v.controller_.ProcessEvent(thresholdEd25519Class().signWithPreviousKey_)
+	var signature = v.signBytes(bytes, true)
+
+	// The previous key shares may only be used once.
+	for _, participant := range v.participants_ {
+		participant.DiscardPreviousKey(signature)
+	}
+	return signature
+}
+
+func (v *thresholdEd25519_) GetRotationSignature() []byte {
+	return v.agreedBytes("rotation signature", Participating.GetRotationSignature)
+}
+
+func (v *thresholdEd25519_) AbortRotation() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to abort a key rotation",
+	)
+
+	v.controller_.ProcessEvent(thresholdEd25519Class().abortRotation_)
+	for _, participant := range v.participants_ {
+		participant.RestorePreviousKey()
+	}
+	return v.GetPublicKey()
+}
+
+func (v *thresholdEd25519_) EraseKeys() {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to erase the keys",
+	)
+
+	for _, participant := range v.participants_ {
+		participant.EraseShares()
+	}
+	v.controller_.SetState(thresholdEd25519Class().keyless_)
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *thresholdEd25519_) agreedBytes(
+	name string,
+	attribute func(Participating) []byte,
+) []byte {
+	// The signers must agree on any shared state since no single participant
+	// can be trusted to report it.
+	var bytes = attribute(v.signers_[0])
+	for _, signer := range v.signers_[1:] {
+		if !byt.Equal(bytes, attribute(signer)) {
+			var message = fmt.Sprintf(
+				"The signers disagree on the %s.",
+				name,
+			)
+			panic(message)
+		}
+	}
+	return bytes
+}
+
+func (v *thresholdEd25519_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"ThresholdEd25519: %s:\n        %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *thresholdEd25519_) generateKeys() []byte {
+	// Each participant commits to a random polynomial.
+	var packages = make([][]byte, len(v.participants_))
+	for index, participant := range v.participants_ {
+		packages[index] = participant.GenerateCommitments(v.threshold_)
+	}
+
+	// Each participant sends a key share to every participant (including
+	// itself) and combines the key shares that it receives.  In a distributed
+	// deployment the key shares must be sent over authenticated and encrypted
+	// channels since the coordinator must never see them.
+	var shares = make([][][]byte, len(v.participants_))
+	for i, recipient := range v.participants_ {
+		shares[i] = make([][]byte, len(v.participants_))
+		for j, sender := range v.participants_ {
+			shares[i][j] = sender.GenerateShare(recipient.GetIdentifier())
+		}
+	}
+	var groupKey []byte
+	for index, recipient := range v.participants_ {
+		var key = recipient.CompleteKeys(packages, shares[index])
+		if uti.IsDefined(groupKey) && !byt.Equal(groupKey, key) {
+			panic("The participants derived different group keys.")
+		}
+		groupKey = key
+	}
+	return groupKey
+}
+
+func (v *thresholdEd25519_) participant(
+	identifier uint,
+) Participating {
+	for _, participant := range v.participants_ {
+		if participant.GetIdentifier() == identifier {
+			return participant
+		}
+	}
+	return nil
+}
+
+func (v *thresholdEd25519_) signBytes(
+	bytes []byte,
+	previous bool,
+) []byte {
+	// Round one: the chosen signers commit to their nonces.
+	var signers = v.signers_
+	var commitments = make([][]byte, len(signers))
+	for index, signer := range signers {
+		commitments[index] = signer.CommitNonces()
+	}
+
+	// Round two: the chosen signers sign their shares.
+	var group []byte
+	var z = ed2.NewScalar()
+	for _, signer := range signers {
+		var share = signer.SignShare(bytes, commitments, previous)
+		if uti.IsDefined(group) && !byt.Equal(group, share[:32]) {
+			panic("The participants derived different group commitments.")
+		}
+		group = share[:32]
+		var scalar, err = ed2.NewScalar().SetCanonicalBytes(share[32:])
+		if err != nil {
+			panic(err)
+		}
+		z.Add(z, scalar)
+	}
+
+	// The aggregate signature is a standard Ed25519 signature.
+	var signature = append(append([]byte{}, group...), z.Bytes()...)
+	var key = v.GetPublicKey()
+	if previous {
+		key = v.GetPreviousKey()
+	}
+	if !sig.Verify(sig.PublicKey(key), bytes, signature) {
+		panic("The aggregate signature is invalid.")
+	}
+	return signature
+}
+
+// Instance Structure
+
+type thresholdEd25519_ struct {
+	// Declare the instance attributes.
+	threshold_    uint
+	participants_ []Participating
+	signers_      []Participating
+	controller_   uti.Stateful
+}
+
+// Class Structure
+
+type thresholdEd25519Class_ struct {
+	// Declare the class constants.
+	algorithm_           string
+	keyless_             uti.State
+	loneKey_             uti.State
+	twoKeys_             uti.State
+	generateKeys_        uti.Event
+	signBytes_           uti.Event
+	rotateKeys_          uti.Event
+	signWithPreviousKey_ uti.Event
+	abortRotation_       uti.Event
+	events_              []uti.Event
+	transitions_         map[uti.State]uti.Transitions
+}
+
+// Class Reference
+
+func thresholdEd25519Class() *thresholdEd25519Class_ {
+	return thresholdEd25519ClassReference_
+}
+
+var thresholdEd25519ClassReference_ = &thresholdEd25519Class_{
+	// Initialize the class constants.
+	algorithm_:           "ED25519",
+	keyless_:             "$Keyless",
+	loneKey_:             "$LoneKey",
+	twoKeys_:             "$TwoKeys",
+	generateKeys_:        "$GenerateKeys",
+	signBytes_:           "$SignBytes",
+	rotateKeys_:          "$RotateKeys",
+	signWithPreviousKey_: "$SignWithPreviousKey",
+	abortRotation_:       "$AbortRotation",
+	events_: []uti.Event{
+		"$GenerateKeys",
+		"$SignBytes",
+		"$RotateKeys",
+		"$SignWithPreviousKey",
+		"$AbortRotation",
+	},
+	transitions_: map[uti.State]uti.Transitions{
+		"$Keyless": uti.Transitions{"$LoneKey", "$Invalid", "$Invalid", "$Invalid", "$Invalid"},
+		"$LoneKey": uti.Transitions{"$Invalid", "$LoneKey", "$TwoKeys", "$Invalid", "$Invalid"},
+		"$TwoKeys": uti.Transitions{"$Invalid", "$Invalid", "$Invalid", "$LoneKey", "$LoneKey"},
+	},
+}
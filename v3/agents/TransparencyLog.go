@@ -0,0 +1,314 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	byt "bytes"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	osx "os"
+	stc "strconv"
+	sts "strings"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func TransparencyLogClass() TransparencyLogClassLike {
+	return transparencyLogClass()
+}
+
+// Constructor Methods
+
+func (c *transparencyLogClass_) TransparencyLog(
+	notary DigitalNotaryLike,
+	ssm Trusted,
+	directory string,
+) TransparencyLogLike {
+	if uti.IsUndefined(notary) {
+		panic("The \"notary\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(ssm) {
+		panic("The \"ssm\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(directory) {
+		panic("The \"directory\" attribute is required by this class.")
+	}
+	uti.MakeDirectory(directory)
+	var instance = &transparencyLog_{
+		// Initialize the instance attributes.
+		notary_:    notary,
+		ssm_:       ssm,
+		tree_:      MerkleTreeClass().MerkleTree(ssm),
+		indices_:   make(map[string]uint),
+		citations_: directory + "/Citations.log",
+		treeHead_:  directory + "/TreeHead.bali",
+	}
+	instance.readLog()
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *transparencyLog_) GetClass() TransparencyLogClassLike {
+	return transparencyLogClass()
+}
+
+// Attribute Methods
+
+func (v *transparencyLog_) GetSize() uint {
+	return v.tree_.GetSize()
+}
+
+// Transparent Methods
+
+func (v *transparencyLog_) AppendCitation(
+	citation com.CitationLike,
+) com.InclusionProofLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to append a citation",
+	)
+
+	// Append the citation to the end of the log.
+	var leaf = citation.AsResource().AsSource()
+	var file, err = osx.OpenFile(
+		v.citations_,
+		osx.O_APPEND|osx.O_CREATE|osx.O_WRONLY,
+		0644,
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer file.Close()
+	_, err = file.WriteString(leaf + "\n")
+	if err != nil {
+		panic(err)
+	}
+	err = file.Sync()
+	if err != nil {
+		panic(err)
+	}
+	v.addLeaf(leaf)
+
+	// Sign the new tree head and prove the inclusion of the citation in it.
+	v.signTreeHead()
+	return v.proveInclusion(leaf)
+}
+
+func (v *transparencyLog_) GetTreeHead() com.DocumentLike {
+	return v.head_
+}
+
+func (v *transparencyLog_) ProveInclusion(
+	citation com.CitationLike,
+) com.InclusionProofLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to prove the inclusion of a citation",
+	)
+
+	var leaf = citation.AsResource().AsSource()
+	return v.proveInclusion(leaf)
+}
+
+func (v *transparencyLog_) ProveConsistency(
+	firstSize uint,
+	secondSize uint,
+) com.ConsistencyProofLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to prove the consistency of the log",
+	)
+
+	var hashes = v.tree_.ProveConsistency(firstSize, secondSize)
+	return com.ConsistencyProofClass().ConsistencyProof(
+		v.algorithm(),
+		firstSize,
+		secondSize,
+		v.formatPath(hashes),
+	)
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *transparencyLog_) addLeaf(
+	leaf string,
+) {
+	var index = v.tree_.AddLeaf([]byte(leaf))
+	if _, ok := v.indices_[leaf]; !ok {
+		v.indices_[leaf] = index
+	}
+}
+
+func (v *transparencyLog_) algorithm() doc.QuoteLike {
+	return doc.Quote(`"` + v.ssm_.GetDigestAlgorithm() + `"`)
+}
+
+func (v *transparencyLog_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"TransparencyLog: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *transparencyLog_) formatPath(
+	hashes [][]byte,
+) []doc.BinaryLike {
+	var path []doc.BinaryLike
+	for _, hash := range hashes {
+		path = append(path, doc.Binary(hash))
+	}
+	return path
+}
+
+func (v *transparencyLog_) proveInclusion(
+	leaf string,
+) com.InclusionProofLike {
+	var index, ok = v.indices_[leaf]
+	if !ok {
+		panic("The citation is not in the transparency log.")
+	}
+	var size = v.tree_.GetSize()
+	var hashes = v.tree_.ProveInclusion(index, size)
+	return com.InclusionProofClass().InclusionProof(
+		v.algorithm(),
+		index,
+		size,
+		v.formatPath(hashes),
+		v.notary_.CiteDocument(v.head_).AsResource(),
+	)
+}
+
+func (v *transparencyLog_) readLog() {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to read in the transparency log",
+	)
+
+	// Rebuild the Merkle tree from the citations.
+	if uti.PathExists(v.citations_) {
+		var source = uti.ReadFile(v.citations_)
+		for _, leaf := range sts.Split(source, "\n") {
+			if len(leaf) > 0 {
+				v.addLeaf(leaf)
+			}
+		}
+	}
+
+	// The last signed tree head must be for a prefix of the citations.
+	if uti.PathExists(v.treeHead_) {
+		var source = uti.ReadFile(v.treeHead_)
+		v.head_ = com.DocumentClass().DocumentFromSource(source)
+		var treeHead = com.TreeHeadClass().TreeHeadFromSource(
+			v.head_.GetContent().AsSource(),
+		)
+		var size = treeHead.GetSize()
+		if size > v.tree_.GetSize() ||
+			treeHead.GetAlgorithm().AsSource() != v.algorithm().AsSource() ||
+			!byt.Equal(treeHead.GetRoot().AsIntrinsic(), v.tree_.GetRoot(size)) {
+			panic("The signed tree head is not consistent with the citations in the log.")
+		}
+	}
+
+	// Sign a new tree head if the log was interrupted before the tree head for
+	// the last citation was written.
+	if v.tree_.GetSize() > 0 {
+		if uti.IsUndefined(v.head_) || v.size(v.head_) != v.tree_.GetSize() {
+			v.signTreeHead()
+		}
+	}
+}
+
+func (v *transparencyLog_) signTreeHead() {
+	// Version N of the tree head is for the first N citations in the log.
+	var size = v.tree_.GetSize()
+	var tag = doc.Tag()
+	var previous doc.ResourceLike
+	if uti.IsDefined(v.head_) {
+		tag = v.head_.GetContent().GetTag()
+		previous = v.notary_.CiteDocument(v.head_).AsResource()
+	}
+	var treeHead = com.TreeHeadClass().TreeHead(
+		v.algorithm(),
+		size,
+		doc.Binary(v.tree_.GetRoot(size)),
+		tag,
+		doc.Version("v"+stc.Itoa(int(size))),
+		previous,
+	)
+	var head = com.DocumentClass().Document(treeHead)
+	v.notary_.NotarizeDocument(head)
+
+	// Write the journal first and then atomically replace the tree head file
+	// so that a crash never leaves a partially written tree head.
+	var journal = v.treeHead_ + ".journal"
+	uti.WriteFile(journal, head.AsSource())
+	uti.RenamePath(journal, v.treeHead_)
+	v.head_ = head
+}
+
+func (v *transparencyLog_) size(
+	head com.DocumentLike,
+) uint {
+	var treeHead = com.TreeHeadClass().TreeHeadFromSource(
+		head.GetContent().AsSource(),
+	)
+	return treeHead.GetSize()
+}
+
+// Instance Structure
+
+type transparencyLog_ struct {
+	// Declare the instance attributes.
+	notary_    DigitalNotaryLike
+	ssm_       Trusted
+	tree_      MerkleTreeLike
+	indices_   map[string]uint
+	citations_ string
+	treeHead_  string
+	head_      com.DocumentLike
+}
+
+// Class Structure
+
+type transparencyLogClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func transparencyLogClass() *transparencyLogClass_ {
+	return transparencyLogClassReference_
+}
+
+var transparencyLogClassReference_ = &transparencyLogClass_{
+	// Initialize the class constants.
+}
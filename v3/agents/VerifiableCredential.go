@@ -0,0 +1,424 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	sig "crypto/ed25519"
+	sha "crypto/sha256"
+	jsn "encoding/json"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	big "math/big"
+	slc "slices"
+	sts "strings"
+	tim "time"
+	u16 "unicode/utf16"
+	utf "unicode/utf8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func VerifiableCredentialClass() VerifiableCredentialClassLike {
+	return verifiableCredentialClass()
+}
+
+// Constructor Methods
+
+// Constant Methods
+
+// Function Methods
+
+func (c *verifiableCredentialClass_) DidKey(
+	certificate com.DocumentLike,
+) string {
+	// Check for any errors at the end.
+	defer c.errorCheck(
+		"An error occurred while attempting to derive a DID from a certificate",
+	)
+
+	var identity = com.IdentityClass().IdentityFromSource(
+		certificate.GetContent().AsSource(),
+	)
+	var algorithm = string(identity.GetAlgorithm().AsIntrinsic())
+	if algorithm != "ED25519" {
+		var message = fmt.Sprintf(
+			"A did:key cannot be derived from a %s key.",
+			algorithm,
+		)
+		panic(message)
+	}
+	var key = append([]byte{0xed, 0x01}, identity.GetKey().AsIntrinsic()...)
+	return c.method_ + "z" + c.encodeBase58(key)
+}
+
+func (c *verifiableCredentialClass_) DidDocument(
+	certificate com.DocumentLike,
+) string {
+	// Check for any errors at the end.
+	defer c.errorCheck(
+		"An error occurred while attempting to create a DID document",
+	)
+
+	var did = c.DidKey(certificate)
+	var method = c.verificationMethod(did)
+	var document = map[string]any{
+		"@context": []string{
+			"https://www.w3.org/ns/did/v1",
+			"https://w3id.org/security/multikey/v1",
+		},
+		"id": did,
+		"verificationMethod": []any{
+			map[string]any{
+				"id":                 method,
+				"type":               "Multikey",
+				"controller":         did,
+				"publicKeyMultibase": sts.TrimPrefix(did, c.method_),
+			},
+		},
+		"authentication":  []string{method},
+		"assertionMethod": []string{method},
+	}
+	return c.formatJSON(document)
+}
+
+func (c *verifiableCredentialClass_) ProofMatches(
+	credential string,
+	issuer string,
+) bool {
+	// Check for any errors at the end.
+	defer c.errorCheck(
+		"An error occurred while attempting to match a credential proof",
+	)
+
+	if uti.IsUndefined(issuer) {
+		panic("The \"issuer\" attribute is required by this function.")
+	}
+
+	// Separate the proof from the credential.
+	var document map[string]any
+	var decoder = jsn.NewDecoder(sts.NewReader(credential))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		panic(err)
+	}
+	var proof, ok = document["proof"].(map[string]any)
+	if !ok {
+		return false
+	}
+	delete(document, "proof")
+	var proofValue, _ = proof["proofValue"].(string)
+	delete(proof, "proofValue")
+	if proof["type"] != "DataIntegrityProof" || proof["cryptosuite"] != c.cryptosuite_ {
+		return false
+	}
+	if proof["proofPurpose"] != "assertionMethod" {
+		return false
+	}
+	if !sts.HasPrefix(proofValue, "z") {
+		return false
+	}
+	var signature = c.decodeBase58(proofValue[1:])
+
+	// The verification method must be the did:key of the expected issuer for
+	// an Ed25519 key.
+	var method, _ = proof["verificationMethod"].(string)
+	var did, fragment, _ = sts.Cut(method, "#")
+	if did != issuer || document["issuer"] != issuer {
+		return false
+	}
+	if !sts.HasPrefix(did, c.method_+"z") || fragment != sts.TrimPrefix(did, c.method_) {
+		return false
+	}
+	var key = c.decodeBase58(sts.TrimPrefix(did, c.method_+"z"))
+	if len(key) != 2+sig.PublicKeySize || key[0] != 0xed || key[1] != 0x01 {
+		return false
+	}
+	proof["@context"] = document["@context"]
+	var hash = c.hashData(document, proof)
+	return sig.Verify(key[2:], hash, signature)
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *verifiableCredentialClass_) decodeBase58(
+	encoded string,
+) []byte {
+	var value = new(big.Int)
+	var base = big.NewInt(58)
+	var zeros int
+	for index, character := range encoded {
+		var digit = sts.IndexRune(c.alphabet_, character)
+		if digit < 0 {
+			panic("An invalid base58 character was found: " + string(character))
+		}
+		if digit == 0 && index == zeros {
+			zeros++
+		}
+		value.Mul(value, base)
+		value.Add(value, big.NewInt(int64(digit)))
+	}
+	return append(make([]byte, zeros), value.Bytes()...)
+}
+
+func (c *verifiableCredentialClass_) encodeBase58(
+	bytes []byte,
+) string {
+	var value = new(big.Int).SetBytes(bytes)
+	var base = big.NewInt(58)
+	var remainder = new(big.Int)
+	var digits []byte
+	for value.Sign() > 0 {
+		value.DivMod(value, base, remainder)
+		digits = append(digits, c.alphabet_[remainder.Int64()])
+	}
+	for _, byte_ := range bytes {
+		if byte_ != 0 {
+			break
+		}
+		digits = append(digits, c.alphabet_[0]) // Leading zeros are preserved.
+	}
+	for left, right := 0, len(digits)-1; left < right; left, right = left+1, right-1 {
+		digits[left], digits[right] = digits[right], digits[left]
+	}
+	return string(digits)
+}
+
+func (c *verifiableCredentialClass_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"VerifiableCredential: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (c *verifiableCredentialClass_) formatJSON(
+	value any,
+) string {
+	// This is the JSON Canonicalization Scheme (RFC 8785) for the values that
+	// may appear in a credential, which do not include numbers.
+	var builder sts.Builder
+	c.formatValue(&builder, value)
+	return builder.String()
+}
+
+func (c *verifiableCredentialClass_) formatString(
+	builder *sts.Builder,
+	value string,
+) {
+	// Only the quotation mark, reverse solidus and control characters are
+	// escaped, using the short forms where they exist.
+	if !utf.ValidString(value) {
+		panic("A JSON string must contain valid UTF-8.")
+	}
+	builder.WriteByte('"')
+	for _, character := range value {
+		switch character {
+		case '"':
+			builder.WriteString(`\"`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if character < 0x20 {
+				fmt.Fprintf(builder, `\u%04x`, character)
+			} else {
+				builder.WriteRune(character)
+			}
+		}
+	}
+	builder.WriteByte('"')
+}
+
+func (c *verifiableCredentialClass_) formatValue(
+	builder *sts.Builder,
+	value any,
+) {
+	switch actual := value.(type) {
+	case nil:
+		builder.WriteString("null")
+	case bool:
+		fmt.Fprintf(builder, "%t", actual)
+	case string:
+		c.formatString(builder, actual)
+	case []string:
+		var values = make([]any, len(actual))
+		for index, item := range actual {
+			values[index] = item
+		}
+		c.formatValue(builder, values)
+	case []any:
+		builder.WriteByte('[')
+		for index, item := range actual {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			c.formatValue(builder, item)
+		}
+		builder.WriteByte(']')
+	case map[string]any:
+		// The keys are sorted by their UTF-16 code units.
+		var keys = make([]string, 0, len(actual))
+		for key := range actual {
+			keys = append(keys, key)
+		}
+		slc.SortFunc(keys, func(first, second string) int {
+			return slc.Compare(
+				u16.Encode([]rune(first)),
+				u16.Encode([]rune(second)),
+			)
+		})
+		builder.WriteByte('{')
+		for index, key := range keys {
+			if index > 0 {
+				builder.WriteByte(',')
+			}
+			c.formatString(builder, key)
+			builder.WriteByte(':')
+			c.formatValue(builder, actual[key])
+		}
+		builder.WriteByte('}')
+	default:
+		var message = fmt.Sprintf(
+			"The JSON value cannot be canonicalized: %v",
+			value,
+		)
+		panic(message)
+	}
+}
+
+func (c *verifiableCredentialClass_) hashData(
+	document map[string]any,
+	options map[string]any,
+) []byte {
+	// The eddsa-jcs-2022 hash data is the hash of the proof options followed
+	// by the hash of the unsecured document.
+	var optionsHash = sha.Sum256([]byte(c.formatJSON(options)))
+	var documentHash = sha.Sum256([]byte(c.formatJSON(document)))
+	return append(optionsHash[:], documentHash[:]...)
+}
+
+func (c *verifiableCredentialClass_) issueCredential(
+	credential com.DocumentLike,
+	citation com.CitationLike,
+	certificate com.DocumentLike,
+	matches func(com.DocumentLike, com.DocumentLike) bool,
+	sign func([]byte) []byte,
+) string {
+	// Make sure the credential was notarized using the certificate.
+	var content = credential.GetContent()
+	if content.GetType().AsSource() != "/bali/types/notary/Credential/v3" {
+		panic("Only a credential document may be issued as a verifiable credential.")
+	}
+	var notaryCitation = credential.GetNotaryCitation()
+	var current = certificate.GetContent()
+	if uti.IsUndefined(notaryCitation) ||
+		notaryCitation.GetTag().AsSource() != current.GetTag().AsSource() ||
+		notaryCitation.GetVersion().AsSource() != current.GetVersion().AsSource() ||
+		!matches(credential, certificate) {
+		panic("The credential was not notarized using the current certificate.")
+	}
+
+	// The context of the credential is included using its JSON form.
+	var context map[string]any
+	var source = com.JSONClass().FormatJSON(doc.ParseComponent(content.AsSource()))
+	if err := jsn.Unmarshal([]byte(source), &context); err != nil {
+		panic(err)
+	}
+	var component = credential.GetSubcomponent(
+		doc.Symbol("$notaries"),
+		-1, // The last notary seal.
+	)
+	var notary = com.NotaryClass().NotaryFromSource(doc.FormatComponent(component))
+	var milliseconds = int64(notary.GetTimestamp().AsIntrinsic())
+	var timestamp = tim.UnixMilli(milliseconds).UTC().Format(tim.RFC3339)
+	var did = c.DidKey(certificate)
+	var resource = citation.AsResource().AsSource()
+	var document = map[string]any{
+		"@context":  []string{c.context_},
+		"id":        resource[1 : len(resource)-1], // Remove the angle brackets.
+		"type":      []string{"VerifiableCredential", "NotaryCredential"},
+		"issuer":    did,
+		"validFrom": timestamp,
+		"credentialSubject": map[string]any{
+			"id":      did,
+			"tag":     content.GetTag().AsSource(),
+			"version": content.GetVersion().AsSource(),
+			"context": context["@value"],
+		},
+	}
+
+	// Add a data integrity proof signed by the notary.
+	var proof = map[string]any{
+		"@context":           document["@context"],
+		"type":               "DataIntegrityProof",
+		"cryptosuite":        c.cryptosuite_,
+		"created":            timestamp,
+		"verificationMethod": c.verificationMethod(did),
+		"proofPurpose":       "assertionMethod",
+	}
+	var signature = sign(c.hashData(document, proof))
+	delete(proof, "@context")
+	proof["proofValue"] = "z" + c.encodeBase58(signature)
+	document["proof"] = proof
+	return c.formatJSON(document)
+}
+
+func (c *verifiableCredentialClass_) verificationMethod(
+	did string,
+) string {
+	return did + "#" + sts.TrimPrefix(did, c.method_)
+}
+
+// Class Structure
+
+type verifiableCredentialClass_ struct {
+	// Declare the class constants.
+	alphabet_    string
+	context_     string
+	cryptosuite_ string
+	method_      string
+}
+
+// Class Reference
+
+func verifiableCredentialClass() *verifiableCredentialClass_ {
+	return verifiableCredentialClassReference_
+}
+
+var verifiableCredentialClassReference_ = &verifiableCredentialClass_{
+	// Initialize the class constants.
+	alphabet_:    "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz",
+	context_:     "https://www.w3.org/ns/credentials/v2",
+	cryptosuite_: "eddsa-jcs-2022",
+	method_:      "did:key:",
+}
@@ -0,0 +1,338 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package agents
+
+import (
+	byt "bytes"
+	cry "crypto"
+	sig "crypto/ed25519"
+	ran "crypto/rand"
+	x50 "crypto/x509"
+	pkx "crypto/x509/pkix"
+	asn "encoding/asn1"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	big "math/big"
+	tim "time"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func X509Class() X509ClassLike {
+	return x509Class()
+}
+
+// Constructor Methods
+
+func (c *x509Class_) X509(
+	arc []uint,
+) X509Like {
+	if len(arc) < 2 || arc[0] > 2 {
+		panic("The \"arc\" attribute must be a valid object identifier.")
+	}
+	var identifier asn.ObjectIdentifier
+	for _, value := range arc {
+		identifier = append(identifier, int(value))
+	}
+	var instance = &x509_{
+		// Initialize the instance attributes.
+		arc_: identifier,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *x509_) GetClass() X509ClassLike {
+	return x509Class()
+}
+
+func (v *x509_) CertificateFromIdentity(
+	identity com.IdentityLike,
+	hsm Hardened,
+	lifetime doc.DurationLike,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to create an X.509 certificate",
+	)
+
+	if uti.IsUndefined(lifetime) {
+		panic("The \"lifetime\" attribute is required by this function.")
+	}
+	var signer = v.signer(identity, hsm)
+	var serialNumber, err = ran.Int(ran.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		panic(err)
+	}
+	var notBefore = tim.Now().UTC().Truncate(tim.Second)
+	var notAfter = notBefore.Add(tim.Duration(lifetime.AsIntrinsic()) * tim.Millisecond)
+	var template = &x50.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               v.subject(identity),
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x50.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		ExtraExtensions:       v.extensions(identity),
+	}
+
+	// The certificate is self-signed by the key in the hardened module.
+	certificate, err := x50.CreateCertificate(
+		ran.Reader,
+		template,
+		template,
+		signer.Public(),
+		signer,
+	)
+	if err != nil {
+		panic(err)
+	}
+	return certificate
+}
+
+func (v *x509_) RequestFromIdentity(
+	identity com.IdentityLike,
+	hsm Hardened,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to create an X.509 certificate request",
+	)
+
+	var signer = v.signer(identity, hsm)
+	var template = &x50.CertificateRequest{
+		Subject:         v.subject(identity),
+		ExtraExtensions: v.extensions(identity),
+	}
+	var request, err = x50.CreateCertificateRequest(ran.Reader, template, signer)
+	if err != nil {
+		panic(err)
+	}
+	return request
+}
+
+func (v *x509_) IdentityFromCertificate(
+	certificate []byte,
+	optionalIssuer []byte,
+) com.IdentityLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to map an X.509 certificate to an identity",
+	)
+
+	// The certificate must be signed by its issuer, or by itself if there is
+	// no issuer, and must currently be valid.
+	var parsed, err = x50.ParseCertificate(certificate)
+	if err != nil {
+		panic(err)
+	}
+	if len(optionalIssuer) > 0 {
+		var issuer, err = x50.ParseCertificate(optionalIssuer)
+		if err != nil {
+			panic(err)
+		}
+		err = parsed.CheckSignatureFrom(issuer)
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		err = parsed.CheckSignature(
+			parsed.SignatureAlgorithm,
+			parsed.RawTBSCertificate,
+			parsed.Signature,
+		)
+		if err != nil {
+			panic(err)
+		}
+	}
+	var now = tim.Now()
+	if now.Before(parsed.NotBefore) || now.After(parsed.NotAfter) {
+		panic("The X.509 certificate is not currently valid.")
+	}
+	var key, ok = parsed.PublicKey.(sig.PublicKey)
+	if !ok {
+		panic("The X.509 certificate does not contain an Ed25519 public key.")
+	}
+
+	// The identity is embedded in the certificate along with its tag and
+	// version, all of which must match the certificate itself.
+	var class = x509Class()
+	var values = make(map[string]string)
+	for _, extension := range parsed.Extensions {
+		for _, identifier := range []asn.ObjectIdentifier{
+			v.identifier(class.tagExtension_),
+			v.identifier(class.versionExtension_),
+			v.identifier(class.identityExtension_),
+		} {
+			if extension.Id.Equal(identifier) {
+				var value string
+				var rest, err = asn.UnmarshalWithParams(extension.Value, &value, "utf8")
+				if err != nil || len(rest) > 0 {
+					panic("An invalid Bali extension was found in the X.509 certificate.")
+				}
+				values[identifier.String()] = value
+			}
+		}
+	}
+	var source, found = values[v.identifier(class.identityExtension_).String()]
+	if !found {
+		panic("The X.509 certificate does not contain a Bali identity.")
+	}
+	var identity = com.IdentityClass().IdentityFromSource(source)
+	if values[v.identifier(class.tagExtension_).String()] != identity.GetTag().AsSource() ||
+		values[v.identifier(class.versionExtension_).String()] != identity.GetVersion().AsSource() {
+		panic("The Bali tag and version of the X.509 certificate do not match its identity.")
+	}
+	if !byt.Equal(key, identity.GetKey().AsIntrinsic()) {
+		panic("The public key of the X.509 certificate does not match its identity.")
+	}
+	return identity
+}
+
+// Attribute Methods
+
+func (v *x509_) GetArc() []uint {
+	var arc []uint
+	for _, value := range v.arc_ {
+		arc = append(arc, uint(value))
+	}
+	return arc
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *x509_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"X509: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *x509_) extension(
+	identifier asn.ObjectIdentifier,
+	value string,
+) pkx.Extension {
+	var bytes, err = asn.MarshalWithParams(value, "utf8")
+	if err != nil {
+		panic(err)
+	}
+	return pkx.Extension{
+		Id:    identifier,
+		Value: bytes,
+	}
+}
+
+func (v *x509_) extensions(
+	identity com.IdentityLike,
+) []pkx.Extension {
+	var class = x509Class()
+	return []pkx.Extension{
+		v.extension(v.identifier(class.tagExtension_), identity.GetTag().AsSource()),
+		v.extension(v.identifier(class.versionExtension_), identity.GetVersion().AsSource()),
+		v.extension(v.identifier(class.identityExtension_), identity.AsSource()),
+	}
+}
+
+func (v *x509_) identifier(
+	extension int,
+) asn.ObjectIdentifier {
+	// The Bali extensions are assigned from the arc and are never marked as
+	// critical.
+	var identifier = append(asn.ObjectIdentifier{}, v.arc_...)
+	return append(identifier, 1, extension)
+}
+
+func (v *x509_) signer(
+	identity com.IdentityLike,
+	hsm Hardened,
+) cry.Signer {
+	if uti.IsUndefined(identity) {
+		panic("The \"identity\" attribute is required by this function.")
+	}
+	if uti.IsUndefined(hsm) {
+		panic("The \"hsm\" attribute is required by this function.")
+	}
+
+	// Only the key in the hardened module may sign for the identity.
+	var algorithm = hsm.GetSignatureAlgorithm()
+	if algorithm != "ED25519" {
+		var message = fmt.Sprintf(
+			"An X.509 certificate cannot be signed using a %s key.",
+			algorithm,
+		)
+		panic(message)
+	}
+	var key = hsm.GetPublicKey()
+	if !byt.Equal(key, identity.GetKey().AsIntrinsic()) {
+		panic("The hardened module does not contain the key for the identity.")
+	}
+	return SignerClass().Signer(hsm)
+}
+
+func (v *x509_) subject(
+	identity com.IdentityLike,
+) pkx.Name {
+	return pkx.Name{
+		CommonName:   identity.GetTag().AsSource(),
+		SerialNumber: identity.GetVersion().AsSource(),
+	}
+}
+
+// Instance Structure
+
+type x509_ struct {
+	// Declare the instance attributes.
+	arc_ asn.ObjectIdentifier
+}
+
+// Class Structure
+
+type x509Class_ struct {
+	// Declare the class constants.
+	tagExtension_      int
+	versionExtension_  int
+	identityExtension_ int
+}
+
+// Class Reference
+
+func x509Class() *x509Class_ {
+	return x509ClassReference_
+}
+
+var x509ClassReference_ = &x509Class_{
+	// Initialize the class constants.  The Bali extensions are numbered within
+	// the arc of each instance.
+	tagExtension_:      1,
+	versionExtension_:  2,
+	identityExtension_: 3,
+}
@@ -30,16 +30,94 @@ on interfaces, not on each other.
 package agents
 
 import (
+	cry "crypto"
 	doc "github.com/bali-nebula/go-bali-documents/v3"
 	com "github.com/bali-nebula/go-digital-notary/v3/components"
 )
 
 // TYPE DECLARATIONS
 
+/*
+These constants name the levels of a version that may be bumped when the next
+version of a document is notarized.  The last level bumps the last ordinal of
+the current version, whatever its length.
+*/
+const (
+	LastLevel int = iota
+	MajorLevel
+	MinorLevel
+	PatchLevel
+)
+
 // FUNCTIONAL DECLARATIONS
 
 // CLASS DECLARATIONS
 
+/*
+AuditFileClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+audit-file-like class.
+
+An audit file is an audit sink that appends each event to a log file in the
+specified directory.  Each entry in the log is a notarized document that is the
+next version of the previous entry and cites it, so any change to an entry can
+be detected.
+*/
+type AuditFileClassLike interface {
+	// Constructor Methods
+	AuditFile(
+		notary DigitalNotaryLike,
+		directory string,
+	) AuditFileLike
+}
+
+/*
+ContentStoreClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete content-store-like class.
+
+A content store is a document repository that keeps each document in the
+specified directory under the digest of its canonical form.  The digest
+algorithm of the security module names the subdirectory that is used as the
+namespace for the digests, so identical documents are only ever stored once.
+Citations that digested an earlier format of a stored document still resolve to
+it.  The references function returns each citation that is found in the source
+for a document, which may be embedded or a resource.
+*/
+type ContentStoreClassLike interface {
+	// Constructor Methods
+	ContentStore(
+		ssm Trusted,
+		directory string,
+	) ContentStoreLike
+
+	// Function Methods
+	References(
+		source string,
+	) []com.CitationLike
+}
+
+/*
+DecisionClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+decision-like class.
+
+A decision evaluates whether the seal on a document is acceptable according to
+a policy at the specified moment.  The trusted certificates are searched for the
+one that notarized the document and for each of its previous versions, which
+must form a valid chain back to a self-signed first version.
+*/
+type DecisionClassLike interface {
+	// Constructor Methods
+	Decision(
+		notary DigitalNotaryLike,
+		policy com.PolicyLike,
+		document com.DocumentLike,
+		certificates []com.DocumentLike,
+		moment doc.MomentLike,
+	) DecisionLike
+}
+
 /*
 DigitalNotaryClassLike is a class interface that declares the complete set of
 class constructors, constants and functions that must be supported by each
@@ -48,6 +126,12 @@ concrete digital-notary-like class.
 A digital notary may be used to digitally notarize digital documents using a
 hardware security module (HSM). It may also be used to validate the seal on a
 document that was notarized using this or any other digital notary.
+
+A digital notary that is restarted using its existing certificate validates the
+seal on that certificate.  When a repository is also supplied it verifies that
+the certificate has not been superseded and that it is certified by each of its
+previous versions, which are retrieved from the repository.  Without one, only
+a self-signed certificate can be validated.
 */
 type DigitalNotaryClassLike interface {
 	// Constructor Methods
@@ -60,6 +144,66 @@ type DigitalNotaryClassLike interface {
 		hsm Hardened,
 		certificate com.DocumentLike,
 	) DigitalNotaryLike
+	DigitalNotaryWithRepository(
+		ssm Trusted,
+		hsm Hardened,
+		certificate com.DocumentLike,
+		repository Resolving,
+	) DigitalNotaryLike
+}
+
+/*
+GuardClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+guard-like class.
+
+A guard enforces the permissions named by the content of notarized documents.
+The repository is used to retrieve the certificates that notarized credentials
+and permissions documents so that their seals can be verified.  Only the trusted
+certificates (or other valid versions of them) are accepted, so each certificate
+tag is bound to the key of a trusted certificate.
+*/
+type GuardClassLike interface {
+	// Constructor Methods
+	Guard(
+		notary DigitalNotaryLike,
+		repository Resolving,
+		certificates []com.DocumentLike,
+	) GuardLike
+}
+
+/*
+HistoryClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete history-like class.
+
+A history traces the versions of a document back through the previous version
+citations of its content.  The repository is used to retrieve each previous
+version and any other versions of the document that may fork its history.
+*/
+type HistoryClassLike interface {
+	// Constructor Methods
+	History(
+		notary DigitalNotaryLike,
+		repository Resolving,
+		document com.DocumentLike,
+	) HistoryLike
+}
+
+/*
+MerkleTreeClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete merkle-tree-like class.
+
+A Merkle tree is the append-only data structure used by a transparency log.  The
+leaf and node hashes, inclusion proofs and consistency proofs are computed as
+described in RFC 9162 using the digest algorithm of the security module.
+*/
+type MerkleTreeClassLike interface {
+	// Constructor Methods
+	MerkleTree(
+		ssm Trusted,
+	) MerkleTreeLike
 }
 
 /*
@@ -72,6 +216,141 @@ type SsmSha512ClassLike interface {
 	SsmSha512() SsmSha512Like
 }
 
+/*
+SsmSha3ClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+software-security-module-sha3-like class.  It digests bytes using SHA3-512 so
+that documents may be resealed using a different digest algorithm.
+*/
+type SsmSha3ClassLike interface {
+	// Constructor Methods
+	SsmSha3() SsmSha3Like
+}
+
+/*
+OpenPGPClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+open-pgp-like class.
+
+The OpenPGP class allows artifacts that are signed by a digital notary to be
+verified using standard OpenPGP tooling like "gpg --verify".  A digital notary
+exports its current Ed25519 key as a transferable public key and signs any byte
+payload with a detached signature using either version 4 (RFC 4880) or version 6
+(RFC 9580) packets.  The signature matching function checks the same packets
+without any external tooling.
+*/
+type OpenPGPClassLike interface {
+	// Function Methods
+	SignatureMatches(
+		publicKey []byte,
+		bytes []byte,
+		signature []byte,
+	) bool
+}
+
+/*
+ParticipantEd25519ClassLike is a class interface that declares the complete set
+of class constructors, constants and functions that must be supported by each
+concrete participant-ed25519-like class.
+
+A participant holds one share of an ED25519 private key that is jointly owned by
+a group of participants.  Its state is persisted in the specified directory.
+*/
+type ParticipantEd25519ClassLike interface {
+	// Constructor Methods
+	ParticipantEd25519(
+		directory string,
+		identifier uint,
+	) ParticipantEd25519Like
+}
+
+/*
+ShamirClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+shamir-like class.
+
+The Shamir class splits a secret into a number of shares such that any subset
+of the shares containing at least the threshold number of shares may be combined
+to recover the secret, but fewer shares reveal nothing about it.  The arithmetic
+is performed byte-wise in GF(256), so each share is one byte longer than the
+secret.
+*/
+type ShamirClassLike interface {
+	// Function Methods
+	SplitSecret(
+		secret []byte,
+		threshold uint,
+		count uint,
+	) [][]byte
+	CombineShares(
+		shares [][]byte,
+	) []byte
+}
+
+/*
+VerifiableCredentialClassLike is a class interface that declares the complete
+set of class constructors, constants and functions that must be supported by
+each concrete verifiable-credential-like class.
+
+The VerifiableCredential class bridges notary certificates and credentials to
+the W3C decentralized identity standards.  An Ed25519 certificate maps to a
+"did:key" identifier and its DID document, and a credential that is issued as a
+W3C Verifiable Credential carries an "eddsa-jcs-2022" Data Integrity proof that
+may be verified without access to the original certificate.  A proof only
+matches if it was made for an assertion by the expected issuer DID.  The JSON
+Canonicalization Scheme (RFC 8785) is only supported for JSON values that do
+not contain numbers.
+*/
+type VerifiableCredentialClassLike interface {
+	// Function Methods
+	DidKey(
+		certificate com.DocumentLike,
+	) string
+	DidDocument(
+		certificate com.DocumentLike,
+	) string
+	ProofMatches(
+		credential string,
+		issuer string,
+	) bool
+}
+
+/*
+SignerClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+signer-like class.
+
+A signer adapts an Ed25519 hardened module to the crypto.Signer interface that
+is required by the standard X.509 functions.
+*/
+type SignerClassLike interface {
+	// Constructor Methods
+	Signer(
+		hsm Hardened,
+	) SignerLike
+}
+
+/*
+X509ClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+x509-like class.
+
+The X509 class bridges notary identities and X.509 certificate chains.  A self-
+signed certificate or a certificate signing request for a CA-signed certificate
+is created for an Ed25519 identity using the hardened module that holds its
+private key.  The Bali tag, version and identity are embedded as non-critical
+extensions so that the identity may be recovered from the certificate.  The
+object identifiers of the extensions are assigned from the specified arc, which
+should be one that is registered to the organization using it (e.g. a private
+enterprise number under 1.3.6.1.4.1).
+*/
+type X509ClassLike interface {
+	// Constructor Methods
+	X509(
+		arc []uint,
+	) X509Like
+}
+
 /*
 HsmEd25519ClassLike is a class interface that declares the complete set of class
 constructors, constants and functions that must be supported by each concrete
@@ -85,12 +364,127 @@ type HsmEd25519ClassLike interface {
 	) HsmEd25519Like
 }
 
+/*
+TransparencyLogClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete transparency-log-like class.
+
+A transparency log is an append-only Merkle log of citations that is persisted
+in the specified directory.  The digital notary is used to notarize each new
+tree head, so the notary for the log should not be the one whose documents are
+being logged unless the log is only used locally.  An existing log is only
+reopened if its signed tree head matches the root of the citations in the log.
+*/
+type TransparencyLogClassLike interface {
+	// Constructor Methods
+	TransparencyLog(
+		notary DigitalNotaryLike,
+		ssm Trusted,
+		directory string,
+	) TransparencyLogLike
+}
+
+/*
+ThresholdEd25519ClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete threshold-ed25519-like class.
+
+A threshold module is a hardened security module that coordinates a group of
+participants using the FROST protocol (RFC 9591) so that any threshold number of
+them jointly produce a standard ED25519 signature without any single participant
+(or the coordinator) ever holding the complete private key.  The private key is
+generated using a distributed key generation protocol.
+
+The signers are chosen by their participant identifiers, and must include at
+least a threshold number of distinct participants.  The first threshold number
+of participants are the signers until other signers are chosen.  Any state that
+the participants share, like the group keys, must be agreed on by all signers.
+*/
+type ThresholdEd25519ClassLike interface {
+	// Constructor Methods
+	ThresholdEd25519(
+		threshold uint,
+		participants []Participating,
+	) ThresholdEd25519Like
+}
+
 // INSTANCE DECLARATIONS
 
+/*
+AuditFileLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete audit-file-like class.  The entries are ordered from the first
+entry in the log.  Each entry must be sealed by a valid version of one of the
+trusted certificates, whose previous versions are retrieved from the repository.
+*/
+type AuditFileLike interface {
+	// Principal Methods
+	GetClass() AuditFileClassLike
+	VerifyEntries(
+		certificates []com.DocumentLike,
+		repository Resolving,
+	) []string
+
+	// Attribute Methods
+	GetEntries() []com.DocumentLike
+
+	// Aspect Interfaces
+	AuditSink
+}
+
+/*
+ContentStoreLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete content-store-like class.  Garbage collection removes each entry
+that cannot be reached from at least one root citation, and a scrub reports each
+entry that no longer matches its digest.
+*/
+type ContentStoreLike interface {
+	// Principal Methods
+	GetClass() ContentStoreClassLike
+	StoreDocument(
+		document com.DocumentLike,
+	) com.CitationLike
+	CollectGarbage(
+		roots []com.CitationLike,
+	) uint
+	ScrubStore() []string
+
+	// Attribute Methods
+	GetSize() uint
+
+	// Aspect Interfaces
+	Resolving
+}
+
+/*
+DecisionLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete decision-like class.  A document is allowed when there are no
+reasons to deny it.
+*/
+type DecisionLike interface {
+	// Principal Methods
+	GetClass() DecisionClassLike
+	IsAllowed() bool
+
+	// Attribute Methods
+	GetPolicy() com.PolicyLike
+	GetDocument() com.DocumentLike
+	GetMoment() doc.MomentLike
+	GetReasons() []string
+}
+
 /*
 DigitalNotaryLike is an instance interface that declares the complete set of
 principal, attribute and aspect methods that must be supported by each instance
-of a concrete digital-notary-like class.
+of a concrete digital-notary-like class.  If a transparency log has been set,
+the certificates and documents that are notarized by the digital notary are
+appended to it and the resulting inclusion proof is added to their last notary.
+Since the inclusion proof becomes part of the document, a citation to the
+document that is created after it was logged differs from the citation that was
+appended to the transparency log.  Seals and citations that were created using
+other algorithms are verified using the legacy security modules for them.
 */
 type DigitalNotaryLike interface {
 	// Principal Methods
@@ -107,6 +501,15 @@ type DigitalNotaryLike interface {
 		attributes doc.Composite,
 	) com.DocumentLike
 	RefreshKey() com.DocumentLike
+	Recover() com.DocumentLike
+	BackupKey(
+		threshold uint,
+		count uint,
+	) []com.DocumentLike
+	RestoreKey(
+		shares []com.DocumentLike,
+		certificate com.DocumentLike,
+	)
 	GenerateCredential(
 		context any,
 	) com.DocumentLike
@@ -114,6 +517,11 @@ type DigitalNotaryLike interface {
 		context any,
 		document com.DocumentLike,
 	) com.DocumentLike
+	NotarizeNextVersion(
+		previous com.DocumentLike,
+		entity any,
+		level int,
+	) com.DocumentLike
 	NotarizeDocument(
 		document com.DocumentLike,
 	)
@@ -121,6 +529,190 @@ type DigitalNotaryLike interface {
 		document com.DocumentLike,
 		certificate com.DocumentLike,
 	) bool
+	// CertificateMatches only returns true if both certificates are valid
+	// versions of the same first version, since anyone may create a
+	// certificate with any tag.
+	CertificateMatches(
+		certificate com.DocumentLike,
+		root com.DocumentLike,
+		repository Resolving,
+	) bool
+	EncryptDocument(
+		document com.DocumentLike,
+		recipients []com.DocumentLike,
+	) com.EnvelopeLike
+	DecryptEnvelope(
+		envelope com.EnvelopeLike,
+		certificate com.DocumentLike,
+	) com.DocumentLike
+	ProofMatches(
+		document com.DocumentLike,
+		treeHead com.DocumentLike,
+	) bool
+	ExportJWS(
+		document com.DocumentLike,
+	) string
+	ExportCOSE(
+		document com.DocumentLike,
+	) []byte
+	ExportCredential(
+		credential com.DocumentLike,
+	) string
+	ExportPGPKey(
+		version uint,
+	) []byte
+	ExportPGPSignature(
+		bytes []byte,
+		version uint,
+	) []byte
+	AssembleBundle(
+		document com.DocumentLike,
+		repository Resolving,
+	) com.DocumentLike
+	// VerifyBundle returns any violations that were found in the evidence
+	// bundle, so an empty list means that it is valid.  Each certificate chain
+	// in the bundle must contain one of the trusted certificates, otherwise
+	// anyone could assemble a valid bundle using certificates of their own.
+	VerifyBundle(
+		bundle com.DocumentLike,
+		certificates []com.DocumentLike,
+	) []string
+	// ResealDocument adds a new notary that seals the document along with all
+	// of its existing notaries, so it should be performed by a digital notary
+	// whose security modules use the strongest available algorithms.
+	ResealDocument(
+		document com.DocumentLike,
+		repository Resolving,
+		trusted []com.DocumentLike,
+	)
+	// VerifyArchive returns any violations that were found in the notaries of
+	// an archived document, so an empty list means that it is valid.  The
+	// certificate for each notary must be a valid version of one of the
+	// trusted certificates, not just a certificate found in the repository.
+	VerifyArchive(
+		document com.DocumentLike,
+		repository Resolving,
+		trusted []com.DocumentLike,
+	) []string
+
+	// Attribute Methods
+	GetOptionalCertificate() com.DocumentLike
+	SetAuditSink(
+		sink AuditSink,
+	)
+	SetTransparencyLog(
+		log Transparent,
+	)
+	SetLegacyModules(
+		ssms []Trusted,
+		hsms []Hardened,
+	)
+}
+
+/*
+GuardLike is an instance interface that declares the complete set of principal,
+attribute and aspect methods that must be supported by each instance of a
+concrete guard-like class.  The owner of a document may always access it, and
+anyone may read a document with public permissions.  Other permissions must be
+added to the guard before they can grant access, and the first version of each
+must be notarized by the trusted owner that was set for its name.
+*/
+type GuardLike interface {
+	// Principal Methods
+	GetClass() GuardClassLike
+
+	// Attribute Methods
+	SetOwner(
+		name doc.NameLike,
+		owner doc.TagLike,
+	)
+	GetOptionalOwner(
+		name doc.NameLike,
+	) doc.TagLike
+	AddPermissions(
+		document com.DocumentLike,
+	)
+	GetOptionalPermissions(
+		name doc.NameLike,
+	) com.PermissionsLike
+	CheckAccess(
+		credential com.DocumentLike,
+		document com.DocumentLike,
+		action string,
+	) bool
+}
+
+/*
+HistoryLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete history-like class.  The versions are ordered from the first
+version to the document itself.
+*/
+type HistoryLike interface {
+	// Principal Methods
+	GetClass() HistoryClassLike
+	IsValid() bool
+
+	// Attribute Methods
+	GetDocument() com.DocumentLike
+	GetVersions() []com.DocumentLike
+	GetForks() []com.DocumentLike
+	GetViolations() []string
+}
+
+/*
+MerkleTreeLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete merkle-tree-like class.  The verification methods do not depend on
+the leaves in the tree.
+*/
+type MerkleTreeLike interface {
+	// Principal Methods
+	GetClass() MerkleTreeClassLike
+	AddLeaf(
+		leaf []byte,
+	) uint
+	GetRoot(
+		size uint,
+	) []byte
+	ProveInclusion(
+		index uint,
+		size uint,
+	) [][]byte
+	ProveConsistency(
+		first uint,
+		second uint,
+	) [][]byte
+	VerifyInclusion(
+		leaf []byte,
+		index uint,
+		size uint,
+		path [][]byte,
+		root []byte,
+	) bool
+	VerifyConsistency(
+		first uint,
+		second uint,
+		firstRoot []byte,
+		secondRoot []byte,
+		path [][]byte,
+	) bool
+
+	// Attribute Methods
+	GetSize() uint
+}
+
+/*
+ParticipantEd25519Like is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete participant-ed25519-like class.
+*/
+type ParticipantEd25519Like interface {
+	// Principal Methods
+	GetClass() ParticipantEd25519ClassLike
+
+	// Aspect Interfaces
+	Participating
 }
 
 /*
@@ -136,6 +728,19 @@ type SsmSha512Like interface {
 	Trusted
 }
 
+/*
+SsmSha3Like is an instance interface that declares the complete set of principal,
+attribute and aspect methods that must be supported by each instance of a
+concrete software-security-module-sha3-like class.
+*/
+type SsmSha3Like interface {
+	// Principal Methods
+	GetClass() SsmSha3ClassLike
+
+	// Aspect Interfaces
+	Trusted
+}
+
 /*
 HsmEd25519Like is an instance interface that declares the complete set of principal,
 attribute and aspect methods that must be supported by each instance of a
@@ -149,6 +754,88 @@ type HsmEd25519Like interface {
 	Hardened
 }
 
+/*
+SignerLike is an instance interface that declares the complete set of principal,
+attribute and aspect methods that must be supported by each instance of a
+concrete signer-like class.
+*/
+type SignerLike interface {
+	// Principal Methods
+	GetClass() SignerClassLike
+
+	// Attribute Methods
+	GetHsm() Hardened
+
+	// Aspect Interfaces
+	cry.Signer
+}
+
+/*
+ThresholdEd25519Like is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete threshold-ed25519-like class.
+*/
+type ThresholdEd25519Like interface {
+	// Principal Methods
+	GetClass() ThresholdEd25519ClassLike
+
+	// Attribute Methods
+	GetThreshold() uint
+	GetParticipants() []Participating
+	GetSigners() []uint
+	SetSigners(
+		identifiers []uint,
+	)
+
+	// Aspect Interfaces
+	Hardened
+}
+
+/*
+TransparencyLogLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete transparency-log-like class.
+*/
+type TransparencyLogLike interface {
+	// Principal Methods
+	GetClass() TransparencyLogClassLike
+
+	// Attribute Methods
+	GetSize() uint
+
+	// Aspect Interfaces
+	Transparent
+}
+
+/*
+X509Like is an instance interface that declares the complete set of principal,
+attribute and aspect methods that must be supported by each instance of a
+concrete x509-like class.  An identity is only recovered from a certificate
+that is currently valid and that is signed by the specified issuer certificate,
+or by itself if no issuer is specified.  Any chain of issuers beyond that must
+be verified by the caller.
+*/
+type X509Like interface {
+	// Principal Methods
+	GetClass() X509ClassLike
+	CertificateFromIdentity(
+		identity com.IdentityLike,
+		hsm Hardened,
+		lifetime doc.DurationLike,
+	) []byte
+	RequestFromIdentity(
+		identity com.IdentityLike,
+		hsm Hardened,
+	) []byte
+	IdentityFromCertificate(
+		certificate []byte,
+		optionalIssuer []byte,
+	) com.IdentityLike
+
+	// Attribute Methods
+	GetArc() []uint
+}
+
 // ASPECT DECLARATIONS
 
 /*
@@ -165,6 +852,24 @@ type Trusted interface {
 /*
 Hardened declares the set of method signatures that must be supported by all
 hardened security modules.  This interface requires a private key.
+
+Key rotation is an explicit two step protocol.  A call to RotateKeys() generates
+a new key pair while retaining the previous private key, and returns the new
+public key.  The previous private key must then be used exactly once by calling
+SignWithPreviousKey() to sign the new certificate, after which it is erased.  No
+other signing operations are allowed while a rotation is pending.
+
+A pending rotation must be recorded persistently so that it survives a restart
+of the security module.  GetPreviousKey() returns the previous public key while
+a rotation is pending (and nil otherwise), and AbortRotation() rolls back the
+pending rotation by discarding the new key pair and reinstating the previous
+one.
+
+The new certificate is passed to RecordRotation() before it is signed, and the
+signature produced by SignWithPreviousKey() is persisted along with it in the
+same step that erases the previous private key.  GetRotationRecord() and
+GetRotationSignature() return them until the next rotation begins, so that a
+rotation interrupted at any point can be completed after a restart.
 */
 type Hardened interface {
 	GetSignatureAlgorithm() string
@@ -179,5 +884,136 @@ type Hardened interface {
 		signature []byte,
 	) bool
 	RotateKeys() []byte
+	GetPreviousKey() []byte
+	RecordRotation(
+		record []byte,
+	)
+	GetRotationRecord() []byte
+	SignWithPreviousKey(
+		bytes []byte,
+	) []byte
+	GetRotationSignature() []byte
+	AbortRotation() []byte
 	EraseKeys()
 }
+
+/*
+Agreeing declares the set of method signatures that must be supported by all
+security modules that can perform a key agreement using a private key that never
+leaves the module.  The agreement key pair changes whenever the signing key pair
+changes.
+*/
+type Agreeing interface {
+	GetAgreementAlgorithm() string
+	GetAgreementKey() []byte
+	AgreeSecret(
+		publicKey []byte,
+	) []byte
+}
+
+/*
+Exportable declares the set of method signatures that must be supported by all
+software security modules that allow their private key to be backed up and
+restored.  Hardware security modules should never implement this interface.
+*/
+type Exportable interface {
+	ExportKey() []byte
+	ImportKey(
+		privateKey []byte,
+	) []byte
+}
+
+/*
+Participating declares the set of method signatures that must be supported by
+all participants in a threshold signing group.  Key generation consists of each
+participant generating its commitments, sending a key share to each participant
+and then completing the generation using the commitments and key shares that it
+received.  Signing consists of each signing participant committing to a pair of
+nonces and then signing its share of the message using the commitments from all
+of the signing participants.  Both the commitments and signature shares are
+encoded as described in RFC 9591.
+
+Each participant also keeps a copy of the pending key rotation record, and
+replaces its previous key share with the signature that was produced using it.
+*/
+type Participating interface {
+	GetIdentifier() uint
+	GetGroupKey() []byte
+	GetPreviousGroupKey() []byte
+	GenerateCommitments(
+		threshold uint,
+	) []byte
+	GenerateShare(
+		recipient uint,
+	) []byte
+	CompleteKeys(
+		packages [][]byte,
+		shares [][]byte,
+	) []byte
+	CommitNonces() []byte
+	SignShare(
+		message []byte,
+		commitments [][]byte,
+		previous bool,
+	) []byte
+	RecordRotation(
+		record []byte,
+	)
+	GetRotationRecord() []byte
+	DiscardPreviousKey(
+		signature []byte,
+	)
+	GetRotationSignature() []byte
+	RestorePreviousKey()
+	EraseShares()
+}
+
+/*
+AuditSink declares the set of method signatures that must be supported by all
+audit sinks.  A digital notary that has an audit sink records an event after
+each operation that changes its keys or produces a notarized document.  The
+subject of the event is a citation to the resulting document (or certificate)
+if there is one.  An audit sink that notarizes its own entries using the same
+digital notary must ignore the events whose subjects are those entries, and
+must be safe to call from concurrent operations.
+*/
+type AuditSink interface {
+	RecordEvent(
+		operation string,
+		optionalSubject com.CitationLike,
+	)
+}
+
+/*
+Transparent declares the set of method signatures that must be supported by all
+transparency logs.  Each citation that is appended to a log is included in a new
+signed tree head, and the inclusion proof that is returned is for that tree
+head.  A consistency proof shows that the tree of the first size is a prefix of
+the tree of the second size.
+*/
+type Transparent interface {
+	AppendCitation(
+		citation com.CitationLike,
+	) com.InclusionProofLike
+	GetTreeHead() com.DocumentLike
+	ProveInclusion(
+		citation com.CitationLike,
+	) com.InclusionProofLike
+	ProveConsistency(
+		firstSize uint,
+		secondSize uint,
+	) com.ConsistencyProofLike
+}
+
+/*
+Resolving declares the set of method signatures that must be supported by all
+document repositories that can resolve citations to notarized documents.
+*/
+type Resolving interface {
+	RetrieveDocument(
+		citation com.CitationLike,
+	) com.DocumentLike
+	RetrieveVersions(
+		tag doc.TagLike,
+	) []com.DocumentLike
+}
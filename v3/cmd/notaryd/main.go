@@ -0,0 +1,157 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+/*
+The "notaryd" command runs a digital notary service over HTTP.  The private key
+of the notary is split between the participants of a threshold security module
+whose state is kept in the specified directory along with the current
+certificate of the notary and each of its previous versions.  The first time the
+service is started the attributes file is used to generate the certificate:
+
+	notaryd -directory ./notary -attributes ./attributes.bali
+
+Clients authenticate using credentials that are notarized by the certificates
+found in the certificates directory.  The certificate of the service itself is
+never accepted for authentication.
+*/
+package main
+
+import (
+	fla "flag"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	not "github.com/bali-nebula/go-digital-notary/v3"
+	ser "github.com/bali-nebula/go-digital-notary/v3/server"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	log "log"
+	htt "net/http"
+	sts "strings"
+)
+
+func main() {
+	var address = fla.String("address", ":8080", "the address to listen on")
+	var directory = fla.String("directory", "./notary", "the state directory")
+	var attributes = fla.String("attributes", "", "the initial certificate attributes")
+	var certificates = fla.String("certificates", "", "the client certificates directory")
+	var threshold = fla.Uint("threshold", 2, "the number of participants required to sign")
+	var count = fla.Uint("participants", 3, "the number of participants")
+	fla.Parse()
+
+	var notary = initializeNotary(*directory, *attributes, *threshold, *count)
+	var repository = &repository_{}
+	if uti.IsDefined(*certificates) {
+		for _, filename := range uti.ReadDirectory(*certificates) {
+			if sts.HasSuffix(filename, ".bali") {
+				var source = uti.ReadFile(*certificates + "/" + filename)
+				repository.addDocument(not.Document(source))
+			}
+		}
+	}
+
+	var service = ser.ServerClass().Server(notary, repository)
+	log.Printf("The digital notary service is listening on %s.", *address)
+	log.Fatal(htt.ListenAndServe(*address, service))
+}
+
+func initializeNotary(
+	directory string,
+	attributes string,
+	threshold uint,
+	count uint,
+) not.DigitalNotaryLike {
+	// Create the threshold security module.
+	var participants []not.Participating
+	for identifier := uint(1); identifier <= count; identifier++ {
+		var participant = not.ParticipantEd25519(directory+"/participants", identifier)
+		participants = append(participants, participant)
+	}
+	var hsm = not.ThresholdEd25519(threshold, participants)
+	var ssm = not.SsmSha512()
+
+	// Reuse the existing certificate, completing any interrupted key rotation.
+	// Each previous version is needed to verify the existing certificate.
+	var filename = directory + "/Certificate.bali"
+	if uti.PathExists(filename) {
+		var versions = &repository_{}
+		var history = directory + "/certificates"
+		if uti.PathExists(history) {
+			for _, name := range uti.ReadDirectory(history) {
+				if sts.HasSuffix(name, ".bali") {
+					versions.addDocument(not.Document(uti.ReadFile(history + "/" + name)))
+				}
+			}
+		}
+		var certificate = not.Document(uti.ReadFile(filename))
+		var notary = not.DigitalNotary(ssm, hsm, certificate, versions)
+		saveCertificate(directory, notary.Recover())
+		return notary
+	}
+
+	// Otherwise generate the initial certificate.
+	if uti.IsUndefined(attributes) {
+		panic(fmt.Sprintf(
+			"An attributes file is required to generate the certificate in: %s",
+			directory,
+		))
+	}
+	var notary = not.DigitalNotary(ssm, hsm)
+	var certificate = notary.GenerateKey(doc.ParseComponent(uti.ReadFile(attributes)))
+	saveCertificate(directory, certificate)
+	return notary
+}
+
+func saveCertificate(
+	directory string,
+	certificate not.DocumentLike,
+) {
+	// Each version is kept along with the current certificate.
+	var history = directory + "/certificates"
+	uti.MakeDirectory(history)
+	var version = certificate.GetContent().GetVersion().AsSource()
+	uti.WriteFile(history+"/"+version+".bali", certificate.AsSource())
+	uti.WriteFile(directory+"/Certificate.bali", certificate.AsSource())
+}
+
+type repository_ struct {
+	documents_ []not.DocumentLike
+}
+
+func (v *repository_) addDocument(
+	document not.DocumentLike,
+) {
+	v.documents_ = append(v.documents_, document)
+}
+
+func (v *repository_) RetrieveDocument(
+	citation not.CitationLike,
+) not.DocumentLike {
+	for _, document := range v.documents_ {
+		var content = document.GetContent()
+		if content.GetTag().AsSource() == citation.GetTag().AsSource() &&
+			content.GetVersion().AsSource() == citation.GetVersion().AsSource() {
+			return document
+		}
+	}
+	return nil
+}
+
+func (v *repository_) RetrieveVersions(
+	tag doc.TagLike,
+) []not.DocumentLike {
+	var versions []not.DocumentLike
+	for _, document := range v.documents_ {
+		if document.GetContent().GetTag().AsSource() == tag.AsSource() {
+			versions = append(versions, document)
+		}
+	}
+	return versions
+}
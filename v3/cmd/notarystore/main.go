@@ -0,0 +1,72 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+/*
+The "notarystore" command maintains a content store that keeps each document
+in the specified directory under its digest.  A scrub reports each entry that
+no longer matches its digest:
+
+	notarystore -directory ./store scrub
+
+Garbage collection removes each entry that cannot be reached from the citations
+found in the specified root files, which may be citations or any documents that
+contain them.  At least one root file is required:
+
+	notarystore -directory ./store collect ./roots/*.bali
+*/
+package main
+
+import (
+	fla "flag"
+	fmt "fmt"
+	not "github.com/bali-nebula/go-digital-notary/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	log "log"
+	osx "os"
+)
+
+func main() {
+	var directory = fla.String("directory", "./store", "the store directory")
+	fla.Parse()
+	if fla.NArg() == 0 {
+		log.Fatal("A command is required: scrub | collect ROOTS...")
+	}
+
+	var store = not.ContentStore(not.SsmSha512(), *directory)
+	switch fla.Arg(0) {
+	case "scrub":
+		var violations = store.ScrubStore()
+		for _, violation := range violations {
+			fmt.Println(violation)
+		}
+		if len(violations) > 0 {
+			osx.Exit(1)
+		}
+		fmt.Printf("All %d entries match their digests.\n", store.GetSize())
+	case "collect":
+		if fla.NArg() < 2 {
+			log.Fatal("At least one root file is required: collect ROOTS...")
+		}
+		var roots []not.CitationLike
+		for _, filename := range fla.Args()[1:] {
+			var source = uti.ReadFile(filename)
+			roots = append(roots, not.ContentStoreClass().References(source)...)
+		}
+		if len(roots) == 0 {
+			log.Fatal("The root files do not contain any citations.")
+		}
+		var count = store.CollectGarbage(roots)
+		fmt.Printf("Removed %d unreferenced entries.\n", count)
+	default:
+		log.Fatalf("An unknown command was specified: %s", fla.Arg(0))
+	}
+}
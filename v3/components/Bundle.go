@@ -0,0 +1,258 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func BundleClass() BundleClassLike {
+	return bundleClass()
+}
+
+// Constructor Methods
+
+func (c *bundleClass_) Bundle(
+	document DocumentLike,
+	tag doc.TagLike,
+	version doc.VersionLike,
+	optionalPrevious doc.ResourceLike,
+) BundleLike {
+	if uti.IsUndefined(document) {
+		panic("The \"document\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(tag) {
+		panic("The \"tag\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(version) {
+		panic("The \"version\" attribute is required by this class.")
+	}
+
+	var previous = "none"
+	if uti.IsDefined(optionalPrevious) {
+		previous = optionalPrevious.AsSource()
+	}
+	var source = `[
+    $document: ` + doc.FormatComponent(document.AsIntrinsic()) + `
+    $certificates: [ ]
+    $status: [ ]
+    $timestamps: [ ]
+](
+    $type: /bali/types/notary/Bundle/v3
+    $tag: ` + tag.AsSource() + `
+    $version: ` + version.AsSource() + `
+    $permissions: /bali/permissions/Public/v3
+    $previous: ` + previous + `
+)`
+	return c.BundleFromSource(source)
+}
+
+func (c *bundleClass_) BundleFromSource(
+	source string,
+) BundleLike {
+	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
+	var instance = &bundle_{
+		// Initialize the instance attributes.
+
+		// Initialize the inherited aspects.
+		Composite: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *bundleClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Bundle/v3")
+	validator.ValidateParameter("$tag", TagKind, false)
+	validator.ValidateParameter("$version", VersionKind, false)
+	validator.ValidateParameter("$permissions", NameKind, false)
+	validator.ValidateParameter("$previous", ResourceKind, true)
+	validator.ValidateAttribute("$document", AttributesKind, false)
+	validator.ValidateAttribute("$certificates", ItemsKind, false)
+	validator.ValidateAttribute("$status", ItemsKind, false)
+	validator.ValidateAttribute("$timestamps", ItemsKind, false)
+	if _, ok := component.GetLiteral().(doc.AttributesLike); ok {
+		var document = component.GetSubcomponent(doc.Symbol("$document"))
+		if uti.IsDefined(document) {
+			validator.ValidateComponent("$document", DocumentClass().Violations(document))
+		}
+	}
+	return validator.GetViolations()
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *bundle_) GetClass() BundleClassLike {
+	return bundleClass()
+}
+
+func (v *bundle_) AsIntrinsic() doc.Composite {
+	return v.Composite
+}
+
+func (v *bundle_) AsSource() string {
+	return doc.FormatComponent(v.Composite) + "\n"
+}
+
+// Attribute Methods
+
+func (v *bundle_) GetDocument() DocumentLike {
+	var component = v.GetSubcomponent(doc.Symbol("$document"))
+	return DocumentClass().DocumentFromSource(doc.FormatComponent(component))
+}
+
+func (v *bundle_) AddCertificate(
+	certificate DocumentLike,
+) {
+	v.SetSubcomponent(
+		certificate.AsIntrinsic(),
+		doc.Symbol("$certificates"),
+		0,
+	)
+}
+
+func (v *bundle_) GetCertificates() []DocumentLike {
+	return v.getDocuments("$certificates")
+}
+
+func (v *bundle_) AddStatus(
+	citation CitationLike,
+) {
+	v.SetSubcomponent(
+		citation.AsIntrinsic(),
+		doc.Symbol("$status"),
+		0,
+	)
+}
+
+func (v *bundle_) GetStatus() []CitationLike {
+	var status []CitationLike
+	var component = v.GetSubcomponent(doc.Symbol("$status"))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var source = doc.FormatComponent(iterator.GetNext())
+		status = append(status, CitationClass().CitationFromSource(source))
+	}
+	return status
+}
+
+func (v *bundle_) AddTimestamp(
+	treeHead DocumentLike,
+) {
+	v.SetSubcomponent(
+		treeHead.AsIntrinsic(),
+		doc.Symbol("$timestamps"),
+		0,
+	)
+}
+
+func (v *bundle_) GetTimestamps() []DocumentLike {
+	return v.getDocuments("$timestamps")
+}
+
+// Parameterized Methods
+
+func (v *bundle_) GetType() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$type"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *bundle_) GetTag() doc.TagLike {
+	var component = v.GetConstraint(doc.Symbol("$tag"))
+	return doc.Tag(doc.FormatComponent(component))
+}
+
+func (v *bundle_) GetVersion() doc.VersionLike {
+	var component = v.GetConstraint(doc.Symbol("$version"))
+	return doc.Version(doc.FormatComponent(component))
+}
+
+func (v *bundle_) GetPermissions() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$permissions"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *bundle_) GetOptionalPrevious() doc.ResourceLike {
+	var previous doc.ResourceLike
+	var component = v.GetConstraint(doc.Symbol("$previous"))
+	if uti.IsDefined(component) {
+		var source = doc.FormatComponent(component)
+		if source != "none" {
+			previous = doc.Resource(source)
+		}
+	}
+	return previous
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *bundleClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Bundle/v3", violations)
+}
+
+func (v *bundle_) getDocuments(
+	key string,
+) []DocumentLike {
+	var documents []DocumentLike
+	var component = v.GetSubcomponent(doc.Symbol(key))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var source = doc.FormatComponent(iterator.GetNext())
+		documents = append(documents, DocumentClass().DocumentFromSource(source))
+	}
+	return documents
+}
+
+// Instance Structure
+
+type bundle_ struct {
+	// Declare the instance attributes.
+
+	// Declare the inherited aspects.
+	doc.Composite
+}
+
+// Class Structure
+
+type bundleClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func bundleClass() *bundleClass_ {
+	return bundleClassReference_
+}
+
+var bundleClassReference_ = &bundleClass_{
+	// Initialize the class constants.
+}
@@ -0,0 +1,454 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	b64 "encoding/base64"
+	bin "encoding/binary"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	mat "math"
+	sts "strings"
+	utf "unicode/utf8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func CanonicalClass() CanonicalClassLike {
+	return canonicalClass()
+}
+
+// Constructor Methods
+
+// Constant Methods
+
+func (c *canonicalClass_) Format() doc.NameLike {
+	return doc.Name(c.format_)
+}
+
+func (c *canonicalClass_) LegacyFormat() doc.NameLike {
+	return doc.Name(c.legacyFormat_)
+}
+
+func (c *canonicalClass_) JWSFormat() doc.NameLike {
+	return doc.Name(c.jwsFormat_)
+}
+
+func (c *canonicalClass_) COSEFormat() doc.NameLike {
+	return doc.Name(c.coseFormat_)
+}
+
+// Function Methods
+
+func (c *canonicalClass_) CanonicalBytes(
+	component doc.Composite,
+) []byte {
+	if uti.IsUndefined(component) {
+		panic("The \"component\" attribute is required by this function.")
+	}
+
+	// The format itself is encoded first so that the bytes for one format can
+	// never be mistaken for the bytes of another format.
+	var bytes = c.encodeValue(nil, 'F', []byte(c.format_))
+	return c.encodeComponent(bytes, component, false)
+}
+
+func (c *canonicalClass_) FormatBytes(
+	component doc.Composite,
+	optionalFormat doc.NameLike,
+) []byte {
+	if uti.IsUndefined(optionalFormat) {
+		// Legacy seals and digests used the formatted source.
+		var source = doc.FormatComponent(component) + "\n"
+		return []byte(source)
+	}
+	var format = optionalFormat.AsSource()
+	switch format {
+	case c.format_:
+		return c.CanonicalBytes(component)
+	case c.legacyFormat_:
+		// The first canonical format encoded most primitives as their source.
+		return c.encodeComponent(nil, component, true)
+	case c.jwsFormat_:
+		// The JWS signing input is the encoded header and payload.
+		var protected, payload = c.payloadBytes(component)
+		var encoding = b64.RawURLEncoding
+		var input = encoding.EncodeToString(protected) + "." +
+			encoding.EncodeToString(payload)
+		return []byte(input)
+	case c.coseFormat_:
+		// The COSE_Sign1 signature structure is a CBOR array containing the
+		// context, protected header, external data and payload.
+		var protected, payload = c.payloadBytes(component)
+		var bytes = c.encodeHead(nil, 4, 4)
+		bytes = c.encodeHead(bytes, 3, 10)
+		bytes = append(bytes, "Signature1"...)
+		bytes = c.encodeHead(bytes, 2, uint64(len(protected)))
+		bytes = append(bytes, protected...)
+		bytes = c.encodeHead(bytes, 2, 0) // No external data.
+		bytes = c.encodeHead(bytes, 2, uint64(len(payload)))
+		return append(bytes, payload...)
+	default:
+		var message = fmt.Sprintf(
+			"An unsupported canonical format was specified: %s",
+			format,
+		)
+		panic(message)
+	}
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *canonicalClass_) decodeHead(
+	bytes []byte,
+	offset int,
+) (
+	major byte,
+	length uint64,
+	next int,
+) {
+	// Decode a CBOR major type and length, which must be definite.
+	if offset >= len(bytes) {
+		panic("The CBOR data ended unexpectedly.")
+	}
+	major = bytes[offset] >> 5
+	var additional = bytes[offset] & 0x1f
+	next = offset + 1
+	var size int
+	switch {
+	case additional < 24:
+		return major, uint64(additional), next
+	case additional == 24:
+		size = 1
+	case additional == 25:
+		size = 2
+	case additional == 26:
+		size = 4
+	case additional == 27:
+		size = 8
+	default:
+		panic("Indefinite length CBOR data is not supported.")
+	}
+	if next+size > len(bytes) {
+		panic("The CBOR data ended unexpectedly.")
+	}
+	for _, value := range bytes[next : next+size] {
+		length = length<<8 | uint64(value)
+	}
+	return major, length, next + size
+}
+
+func (c *canonicalClass_) decodeString(
+	bytes []byte,
+	offset int,
+	major byte,
+) (
+	value []byte,
+	next int,
+) {
+	// Decode a CBOR byte or text string of the specified major type.
+	actual, length, next := c.decodeHead(bytes, offset)
+	if actual != major || uint64(len(bytes)-next) < length {
+		panic("The CBOR data does not contain the expected string.")
+	}
+	next += int(length)
+	return bytes[next-int(length) : next], next
+}
+
+func (c *canonicalClass_) encodeComponent(
+	bytes []byte,
+	component doc.Composite,
+	legacy bool,
+) []byte {
+	// Encode the literal value of the component.
+	bytes = c.encodeLiteral(bytes, component.GetLiteral(), legacy)
+
+	// Encode any parameters in document order.  Notes are not included.
+	var parameters = component.GetOptionalParameters()
+	if uti.IsUndefined(parameters) {
+		return append(bytes, 'N')
+	}
+	var constraints = parameters.GetConstraints()
+	bytes = append(bytes, 'P')
+	bytes = bin.AppendUvarint(bytes, uint64(constraints.GetSize()))
+	var iterator = constraints.GetIterator()
+	for iterator.HasNext() {
+		var association = iterator.GetNext()
+		bytes = c.encodeLiteral(bytes, association.GetKey(), legacy)
+		bytes = c.encodeComponent(bytes, association.GetValue(), legacy)
+	}
+	return bytes
+}
+
+func (c *canonicalClass_) encodeHead(
+	bytes []byte,
+	major byte,
+	length uint64,
+) []byte {
+	// Encode a CBOR major type and length using the shortest form.
+	major <<= 5
+	switch {
+	case length < 24:
+		return append(bytes, major|byte(length))
+	case length <= 0xff:
+		return append(bytes, major|24, byte(length))
+	case length <= 0xffff:
+		return bin.BigEndian.AppendUint16(append(bytes, major|25), uint16(length))
+	case length <= 0xffffffff:
+		return bin.BigEndian.AppendUint32(append(bytes, major|26), uint32(length))
+	default:
+		return bin.BigEndian.AppendUint64(append(bytes, major|27), length)
+	}
+}
+
+func (c *canonicalClass_) encodeFloat(
+	bytes []byte,
+	value float64,
+) []byte {
+	// Negative zero and all NaN values have a single encoding.
+	switch {
+	case value == 0:
+		value = 0
+	case mat.IsNaN(value):
+		value = mat.NaN()
+	}
+	return bin.BigEndian.AppendUint64(bytes, mat.Float64bits(value))
+}
+
+func (c *canonicalClass_) encodeLiteral(
+	bytes []byte,
+	literal any,
+	legacy bool,
+) []byte {
+	switch actual := literal.(type) {
+	case doc.ItemsLike:
+		var components = actual.GetComponents()
+		bytes = append(bytes, 'L')
+		bytes = bin.AppendUvarint(bytes, uint64(components.GetSize()))
+		var iterator = components.GetIterator()
+		for iterator.HasNext() {
+			bytes = c.encodeComponent(bytes, iterator.GetNext(), legacy)
+		}
+	case doc.AttributesLike:
+		var associations = actual.GetAssociations()
+		bytes = append(bytes, 'A')
+		bytes = bin.AppendUvarint(bytes, uint64(associations.GetSize()))
+		var iterator = associations.GetIterator()
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			bytes = c.encodeLiteral(bytes, association.GetKey(), legacy)
+			bytes = c.encodeComponent(bytes, association.GetValue(), legacy)
+		}
+	case doc.BinaryLike:
+		// Binary values are encoded as their raw bytes.
+		bytes = c.encodeValue(bytes, 'B', actual.AsIntrinsic())
+	default:
+		if legacy {
+			return c.encodeSource(bytes, literal)
+		}
+		return c.encodePrimitive(bytes, literal)
+	}
+	return bytes
+}
+
+func (c *canonicalClass_) encodePrimitive(
+	bytes []byte,
+	literal any,
+) []byte {
+	// Each primitive value is encoded as its intrinsic value so that the
+	// encoding does not depend on how the value happens to be formatted.
+	switch actual := literal.(type) {
+	case doc.AngleLike:
+		bytes = c.encodeValue(bytes, 'a', c.encodeFloat(nil, actual.AsIntrinsic()))
+	case doc.BooleanLike:
+		var value = []byte{0}
+		if actual.AsIntrinsic() {
+			value[0] = 1
+		}
+		bytes = c.encodeValue(bytes, 'b', value)
+	case doc.DurationLike:
+		var value = bin.AppendUvarint(nil, uint64(actual.AsIntrinsic()))
+		bytes = c.encodeValue(bytes, 'd', value)
+	case doc.GlyphLike:
+		var value = utf.AppendRune(nil, actual.AsIntrinsic())
+		bytes = c.encodeValue(bytes, 'g', value)
+	case doc.MomentLike:
+		var value = bin.AppendVarint(nil, int64(actual.AsIntrinsic()))
+		bytes = c.encodeValue(bytes, 'm', value)
+	case doc.NumberLike:
+		var value = c.encodeFloat(nil, actual.GetReal())
+		value = c.encodeFloat(value, actual.GetImaginary())
+		bytes = c.encodeValue(bytes, 'n', value)
+	case doc.PercentageLike:
+		bytes = c.encodeValue(bytes, 'p', c.encodeFloat(nil, actual.AsIntrinsic()))
+	case doc.ProbabilityLike:
+		bytes = c.encodeValue(bytes, 'q', c.encodeFloat(nil, actual.AsIntrinsic()))
+	case doc.ResourceLike:
+		bytes = c.encodeValue(bytes, 'r', []byte(actual.AsIntrinsic()))
+	case doc.BytecodeLike:
+		var value []byte
+		for _, instruction := range actual.AsIntrinsic() {
+			value = bin.BigEndian.AppendUint16(value, instruction)
+		}
+		bytes = c.encodeValue(bytes, 'C', value)
+	case doc.IdentifierLike:
+		bytes = c.encodeValue(bytes, 'I', []byte(string(actual.AsIntrinsic())))
+	case doc.NameLike:
+		bytes = c.encodeValue(bytes, 'M', c.encodeStrings(actual.AsIntrinsic()))
+	case doc.NarrativeLike:
+		var lines = c.normalizeLines(actual.AsIntrinsic())
+		bytes = c.encodeValue(bytes, 'T', c.encodeStrings(lines))
+	case doc.PatternLike:
+		bytes = c.encodeValue(bytes, 'R', []byte(string(actual.AsIntrinsic())))
+	case doc.QuoteLike:
+		bytes = c.encodeValue(bytes, 'Q', []byte(string(actual.AsIntrinsic())))
+	case doc.SymbolLike:
+		bytes = c.encodeValue(bytes, 'S', []byte(string(actual.AsIntrinsic())))
+	case doc.TagLike:
+		bytes = c.encodeValue(bytes, 'G', actual.AsIntrinsic())
+	case doc.VersionLike:
+		var value []byte
+		for _, ordinal := range actual.AsIntrinsic() {
+			value = bin.AppendUvarint(value, uint64(ordinal))
+		}
+		bytes = c.encodeValue(bytes, 'V', value)
+	case doc.RangeLike:
+		// A range is encoded as its brackets and any endpoints.
+		bytes = append(bytes, 'W', byte(actual.GetLeft()))
+		for _, endpoint := range []any{
+			actual.GetOptionalFirst(),
+			actual.GetOptionalLast(),
+		} {
+			if uti.IsUndefined(endpoint) {
+				bytes = append(bytes, 'N')
+				continue
+			}
+			bytes = c.encodePrimitive(bytes, endpoint)
+		}
+		bytes = append(bytes, byte(actual.GetRight()))
+	default:
+		var message = fmt.Sprintf(
+			"The canonical format does not support procedural literals: %T",
+			literal,
+		)
+		panic(message)
+	}
+	return bytes
+}
+
+func (c *canonicalClass_) encodeSource(
+	bytes []byte,
+	literal any,
+) []byte {
+	switch actual := literal.(type) {
+	case interface{ AsSource() string }:
+		// All other primitive values are encoded as their normalized source.
+		bytes = c.encodeValue(bytes, 'E', []byte(actual.AsSource()))
+	default:
+		// Procedural literals are encoded as their formatted source.
+		var source = doc.FormatComponent(actual)
+		bytes = c.encodeValue(bytes, 'X', []byte(source))
+	}
+	return bytes
+}
+
+func (c *canonicalClass_) encodeStrings(
+	strings []string,
+) []byte {
+	var bytes = bin.AppendUvarint(nil, uint64(len(strings)))
+	for _, value := range strings {
+		bytes = bin.AppendUvarint(bytes, uint64(len(value)))
+		bytes = append(bytes, value...)
+	}
+	return bytes
+}
+
+func (c *canonicalClass_) encodeValue(
+	bytes []byte,
+	kind byte,
+	value []byte,
+) []byte {
+	bytes = append(bytes, kind)
+	bytes = bin.AppendUvarint(bytes, uint64(len(value)))
+	return append(bytes, value...)
+}
+
+func (c *canonicalClass_) normalizeLines(
+	lines []string,
+) []string {
+	// The lines of a narrative are indented to match the depth at which it is
+	// formatted, so the common indentation is removed along with any blank
+	// lines that only contain indentation.
+	var indentation = -1
+	for _, line := range lines {
+		var trimmed = sts.TrimLeft(line, " ")
+		if len(trimmed) > 0 {
+			var length = len(line) - len(trimmed)
+			if indentation < 0 || length < indentation {
+				indentation = length
+			}
+		}
+	}
+	var normalized = make([]string, len(lines))
+	for index, line := range lines {
+		if len(sts.TrimLeft(line, " ")) > 0 {
+			normalized[index] = line[indentation:]
+		}
+	}
+	return normalized
+}
+
+func (c *canonicalClass_) payloadBytes(
+	component doc.Composite,
+) (
+	protected []byte,
+	payload []byte,
+) {
+	var content = component.GetSubcomponent(doc.Symbol("$content"))
+	if uti.IsUndefined(content) {
+		panic("Only a document containing a payload may use this format.")
+	}
+	var entity = PayloadClass().PayloadFromSource(doc.FormatComponent(content))
+	protected = entity.GetProtected().AsIntrinsic()
+	payload = entity.GetPayload().AsIntrinsic()
+	return protected, payload
+}
+
+// Class Structure
+
+type canonicalClass_ struct {
+	// Declare the class constants.
+	format_       string
+	legacyFormat_ string
+	jwsFormat_    string
+	coseFormat_   string
+}
+
+// Class Reference
+
+func canonicalClass() *canonicalClass_ {
+	return canonicalClassReference_
+}
+
+var canonicalClassReference_ = &canonicalClass_{
+	// Initialize the class constants.
+	format_:       "/bali/formats/Canonical/v2",
+	legacyFormat_: "/bali/formats/Canonical/v1",
+	jwsFormat_:    "/bali/formats/JWS/v1",
+	coseFormat_:   "/bali/formats/COSE/v1",
+}
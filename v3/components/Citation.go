@@ -13,8 +13,11 @@
 package components
 
 import (
+	b64 "encoding/base64"
+	fmt "fmt"
 	doc "github.com/bali-nebula/go-bali-documents/v3"
 	uti "github.com/craterdog/go-essential-utilities/v8"
+	reg "regexp"
 	sts "strings"
 )
 
@@ -60,6 +63,7 @@ func (c *citationClass_) CitationFromSource(
 	source string,
 ) CitationLike {
 	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
 	var instance = &citation_{
 		// Initialize the instance attributes.
 
@@ -69,33 +73,50 @@ func (c *citationClass_) CitationFromSource(
 	return instance
 }
 
+func (c *citationClass_) CitationFromJSON(
+	source string,
+) CitationLike {
+	var component = JSONClass().ParseJSON(source)
+	return c.CitationFromSource(doc.FormatComponent(component))
+}
+
 func (c *citationClass_) CitationFromResource(
 	resource doc.ResourceLike,
 ) CitationLike {
-	// Parse parts of the path.
-	var path = resource.GetPath()
-	var parts = sts.Split(path, "/")
-	parts = sts.Split(parts[1], ":")
-	var tag = doc.Tag("#" + parts[0])
-	var version = doc.Version(parts[1])
-
-	// Parse parts of the query string.
-	var query = resource.GetQuery()
-	parts = sts.Split(query, "=")
-	var algorithm = parts[0]
-	var digest = parts[1]
-	digest = sts.ReplaceAll(digest, "-", "+")
-	digest = sts.ReplaceAll(digest, "_", "/")
-	digest = "'>\n    " + digest[:60] + "\n    " + digest[60:] + "\n<'"
+	if uti.IsUndefined(resource) {
+		panic("The \"resource\" attribute is required by this class.")
+	}
+
+	// Parse the resource using the citation resource grammar:
+	//   citation:  "nebula:/" tag ":" version "?" algorithm "=" digest
+	//   tag:       base 32 characters
+	//   version:   "v" ordinal {"." ordinal}
+	//   algorithm: uppercase letter {uppercase letter | digit | "-"}
+	//   digest:    unpadded base 64 URL characters
+	var uri = resource.AsIntrinsic()
+	if !sts.HasPrefix(uri, c.scheme_) {
+		c.invalidResource(uri, "it must begin with \""+c.scheme_+"\"")
+	}
+	var matches = c.matcher_.FindStringSubmatch(uri)
+	if uti.IsUndefined(matches) {
+		c.diagnoseResource(uri)
+	}
+	var tag = doc.Tag("#" + matches[1])
+	var version = doc.Version(matches[2])
+	var algorithm = doc.Quote(`"` + matches[3] + `"`)
+	var bytes, err = b64.RawURLEncoding.Strict().DecodeString(matches[4])
+	if err != nil || len(bytes) == 0 {
+		c.invalidResource(uri, "the digest is not valid base 64 URL encoding")
+	}
+	var digest = doc.Binary(bytes)
 
 	// Construct the citation.
 	var instance = c.Citation(
-		doc.Tag(tag),
-		doc.Version(version),
-		doc.Quote(algorithm),
-		doc.Binary(digest),
+		tag,
+		version,
+		algorithm,
+		digest,
 	)
-
 	return instance
 }
 
@@ -103,6 +124,19 @@ func (c *citationClass_) CitationFromResource(
 
 // Function Methods
 
+func (c *citationClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Citation/v3")
+	validator.ValidateAttribute("$tag", TagKind, false)
+	validator.ValidateAttribute("$version", VersionKind, false)
+	validator.ValidateAttribute("$algorithm", QuoteKind, false)
+	validator.ValidateAttribute("$digest", BinaryKind, false)
+	validator.ValidateSize("$digest", "SHA512", 64)
+	return validator.GetViolations()
+}
+
 // INSTANCE INTERFACE
 
 // Principal Methods
@@ -119,21 +153,26 @@ func (v *citation_) AsSource() string {
 	return doc.FormatComponent(v.Composite) + "\n"
 }
 
+func (v *citation_) AsJSON() string {
+	return JSONClass().FormatJSON(v.Composite)
+}
+
 func (v *citation_) AsResource() doc.ResourceLike {
+	var class = citationClass()
+	var tag = v.GetTag().AsSource()[1:] // Remove the leading "#".
+	var version = v.GetVersion().AsSource()
 	var algorithm = v.GetAlgorithm().AsSource()
 	algorithm = algorithm[1 : len(algorithm)-1] // Remove the double quotes.
-	var digest = v.GetDigest().AsSource()
-	digest = digest[2 : len(digest)-2]
-	digest = sts.ReplaceAll(digest, " ", "")
-	digest = sts.ReplaceAll(digest, "\n", "")
-	digest = sts.ReplaceAll(digest, "+", "-")
-	digest = sts.ReplaceAll(digest, "/", "_")
-	var tag = v.GetTag().AsSource()[1:]
-	var version = v.GetVersion().AsSource()
-	var source = "<nebula:/" + tag + ":" + version + "?" +
-		algorithm + "=" + digest + ">"
-	var resource = doc.Resource(source)
-	return resource
+	if !class.algorithm_.MatchString(algorithm) {
+		var message = fmt.Sprintf(
+			"The citation algorithm cannot be used in a resource: %q",
+			algorithm,
+		)
+		panic(message)
+	}
+	var digest = b64.RawURLEncoding.EncodeToString(v.GetDigest().AsIntrinsic())
+	var uri = class.scheme_ + tag + ":" + version + "?" + algorithm + "=" + digest
+	return doc.Resource("<" + uri + ">")
 }
 
 // Attribute Methods
@@ -163,6 +202,50 @@ func (v *citation_) GetDigest() doc.BinaryLike {
 
 // Private Methods
 
+func (c *citationClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Citation/v3", violations)
+}
+
+func (c *citationClass_) diagnoseResource(
+	uri string,
+) {
+	// Determine which part of the resource is invalid.
+	var path, query, found = sts.Cut(uri[len(c.scheme_):], "?")
+	if !found {
+		c.invalidResource(uri, "it is missing the \"?ALGORITHM=DIGEST\" query")
+	}
+	var tag, version, _ = sts.Cut(path, ":")
+	if !c.tag_.MatchString(tag) {
+		c.invalidResource(uri, "the tag must contain only base 32 characters")
+	}
+	if !c.version_.MatchString(version) {
+		c.invalidResource(uri, "the version must have the form \"v1.2.3\"")
+	}
+	var algorithm, digest, _ = sts.Cut(query, "=")
+	if !c.algorithm_.MatchString(algorithm) {
+		c.invalidResource(uri, "the algorithm must be an uppercase name like \"SHA512\"")
+	}
+	if !c.digest_.MatchString(digest) {
+		c.invalidResource(uri, "the digest is not valid base 64 URL encoding")
+	}
+	c.invalidResource(uri, "it does not match the citation resource grammar")
+}
+
+func (c *citationClass_) invalidResource(
+	uri string,
+	reason string,
+) {
+	var message = fmt.Sprintf(
+		"An invalid citation resource was specified, %s: <%s>",
+		reason,
+		uri,
+	)
+	panic(message)
+}
+
 // Instance Structure
 
 type citation_ struct {
@@ -176,6 +259,12 @@ type citation_ struct {
 
 type citationClass_ struct {
 	// Declare the class constants.
+	scheme_    string
+	tag_       *reg.Regexp
+	version_   *reg.Regexp
+	algorithm_ *reg.Regexp
+	digest_    *reg.Regexp
+	matcher_   *reg.Regexp
 }
 
 // Class Reference
@@ -186,4 +275,13 @@ func citationClass() *citationClass_ {
 
 var citationClassReference_ = &citationClass_{
 	// Initialize the class constants.
+	scheme_:    "nebula:/",
+	tag_:       reg.MustCompile(`^[0-9A-DF-HJ-NP-TV-Z]+$`),
+	version_:   reg.MustCompile(`^v[1-9][0-9]*(?:\.[1-9][0-9]*)*$`),
+	algorithm_: reg.MustCompile(`^[A-Z][A-Z0-9-]*$`),
+	digest_:    reg.MustCompile(`^[A-Za-z0-9_-]+$`),
+	matcher_: reg.MustCompile(
+		`^nebula:/([0-9A-DF-HJ-NP-TV-Z]+):(v[1-9][0-9]*(?:\.[1-9][0-9]*)*)` +
+			`\?([A-Z][A-Z0-9-]*)=([A-Za-z0-9_-]+)$`,
+	),
 }
@@ -0,0 +1,165 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	stc "strconv"
+	sts "strings"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func ConsistencyProofClass() ConsistencyProofClassLike {
+	return consistencyProofClass()
+}
+
+// Constructor Methods
+
+func (c *consistencyProofClass_) ConsistencyProof(
+	algorithm doc.QuoteLike,
+	firstSize uint,
+	secondSize uint,
+	path []doc.BinaryLike,
+) ConsistencyProofLike {
+	if uti.IsUndefined(algorithm) {
+		panic("The \"algorithm\" attribute is required by this class.")
+	}
+
+	var hashes []string
+	for _, hash := range path {
+		hashes = append(hashes, hash.AsSource())
+	}
+	var source = `[
+    $algorithm: ` + algorithm.AsSource() + `
+    $firstSize: ` + stc.Itoa(int(firstSize)) + `
+    $secondSize: ` + stc.Itoa(int(secondSize)) + `
+    $path: [ ` + sts.Join(hashes, " ") + ` ]
+]($type: /bali/types/notary/ConsistencyProof/v3)`
+	return c.ConsistencyProofFromSource(source)
+}
+
+func (c *consistencyProofClass_) ConsistencyProofFromSource(
+	source string,
+) ConsistencyProofLike {
+	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
+	var instance = &consistencyProof_{
+		// Initialize the instance attributes.
+
+		// Initialize the inherited aspects.
+		Composite: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *consistencyProofClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/ConsistencyProof/v3")
+	validator.ValidateAttribute("$algorithm", QuoteKind, false)
+	validator.ValidateAttribute("$firstSize", NumberKind, false)
+	validator.ValidateAttribute("$secondSize", NumberKind, false)
+	validator.ValidateAttribute("$path", ItemsKind, false)
+	return validator.GetViolations()
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *consistencyProof_) GetClass() ConsistencyProofClassLike {
+	return consistencyProofClass()
+}
+
+func (v *consistencyProof_) AsIntrinsic() doc.Composite {
+	return v.Composite
+}
+
+func (v *consistencyProof_) AsSource() string {
+	return doc.FormatComponent(v.Composite) + "\n"
+}
+
+// Attribute Methods
+
+func (v *consistencyProof_) GetAlgorithm() doc.QuoteLike {
+	var component = v.GetSubcomponent(doc.Symbol("$algorithm"))
+	return doc.Quote(doc.FormatComponent(component))
+}
+
+func (v *consistencyProof_) GetFirstSize() uint {
+	var component = v.GetSubcomponent(doc.Symbol("$firstSize"))
+	var size, _ = stc.Atoi(doc.FormatComponent(component))
+	return uint(size)
+}
+
+func (v *consistencyProof_) GetSecondSize() uint {
+	var component = v.GetSubcomponent(doc.Symbol("$secondSize"))
+	var size, _ = stc.Atoi(doc.FormatComponent(component))
+	return uint(size)
+}
+
+func (v *consistencyProof_) GetPath() []doc.BinaryLike {
+	var path []doc.BinaryLike
+	var component = v.GetSubcomponent(doc.Symbol("$path"))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var hash = iterator.GetNext()
+		path = append(path, doc.Binary(doc.FormatComponent(hash)))
+	}
+	return path
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *consistencyProofClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/ConsistencyProof/v3", violations)
+}
+
+// Instance Structure
+
+type consistencyProof_ struct {
+	// Declare the instance attributes.
+
+	// Declare the inherited aspects.
+	doc.Composite
+}
+
+// Class Structure
+
+type consistencyProofClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func consistencyProofClass() *consistencyProofClass_ {
+	return consistencyProofClassReference_
+}
+
+var consistencyProofClassReference_ = &consistencyProofClass_{
+	// Initialize the class constants.
+}
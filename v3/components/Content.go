@@ -69,6 +69,7 @@ func (c *contentClass_) ContentFromSource(
 	source string,
 ) ContentLike {
 	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
 	var instance = &content_{
 		// Initialize the instance attributes.
 
@@ -82,6 +83,18 @@ func (c *contentClass_) ContentFromSource(
 
 // Function Methods
 
+func (c *contentClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateParameter("$type", NameKind, false)
+	validator.ValidateParameter("$tag", TagKind, false)
+	validator.ValidateParameter("$version", VersionKind, false)
+	validator.ValidateParameter("$permissions", NameKind, false)
+	validator.ValidateParameter("$previous", ResourceKind, true)
+	return validator.GetViolations()
+}
+
 // INSTANCE INTERFACE
 
 // Principal Methods
@@ -138,6 +151,13 @@ func (v *content_) GetOptionalPrevious() doc.ResourceLike {
 
 // Private Methods
 
+func (c *contentClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("notary content", violations)
+}
+
 // Instance Structure
 
 type content_ struct {
@@ -13,8 +13,12 @@
 package components
 
 import (
+	sha "crypto/sha512"
+	b64 "encoding/base64"
+	fmt "fmt"
 	doc "github.com/bali-nebula/go-bali-documents/v3"
 	uti "github.com/craterdog/go-essential-utilities/v8"
+	sts "strings"
 )
 
 // CLASS INTERFACE
@@ -45,6 +49,7 @@ func (c *documentClass_) DocumentFromSource(
 	source string,
 ) DocumentLike {
 	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
 	var instance = &document_{
 		// Initialize the instance attributes.
 
@@ -54,10 +59,114 @@ func (c *documentClass_) DocumentFromSource(
 	return instance
 }
 
+func (c *documentClass_) DocumentFromJSON(
+	source string,
+) DocumentLike {
+	var component = JSONClass().ParseJSON(source)
+	return c.DocumentFromSource(doc.FormatComponent(component))
+}
+
+func (c *documentClass_) DocumentFromJWS(
+	source string,
+) DocumentLike {
+	var parts = sts.Split(source, ".")
+	if len(parts) != 3 {
+		panic("A compact JWS must contain exactly three parts.")
+	}
+	var values [][]byte
+	for _, part := range parts {
+		var bytes, err = b64.RawURLEncoding.DecodeString(part)
+		if err != nil {
+			panic(err)
+		}
+		values = append(values, bytes)
+	}
+	var format = CanonicalClass().JWSFormat()
+	return c.payloadDocument(values[0], values[1], values[2], format)
+}
+
+func (c *documentClass_) DocumentFromCOSE(
+	bytes []byte,
+) DocumentLike {
+	// The COSE_Sign1 structure may be preceded by its CBOR tag (18).
+	var canonical = canonicalClass()
+	var next int
+	if len(bytes) > 0 && bytes[0] == 0xd2 {
+		next = 1
+	}
+	var major byte
+	var count uint64
+	major, count, next = canonical.decodeHead(bytes, next)
+	if major != 4 || count != 4 {
+		panic("A COSE_Sign1 structure must be a CBOR array of four items.")
+	}
+	var protected, payload, signature []byte
+	protected, next = canonical.decodeString(bytes, next, 2)
+	major, count, next = canonical.decodeHead(bytes, next)
+	if major != 5 || count != 0 {
+		panic("The unprotected header of a COSE_Sign1 structure must be empty.")
+	}
+	payload, next = canonical.decodeString(bytes, next, 2)
+	signature, next = canonical.decodeString(bytes, next, 2)
+	if next != len(bytes) {
+		panic("The COSE_Sign1 structure is followed by extra data.")
+	}
+	var format = CanonicalClass().COSEFormat()
+	return c.payloadDocument(protected, payload, signature, format)
+}
+
 // Constant Methods
 
 // Function Methods
 
+func (c *documentClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Document/v3")
+	validator.ValidateAttribute("$content", AnyKind, false)
+	validator.ValidateAttribute("$notaries", ItemsKind, false)
+	if _, ok := component.GetLiteral().(doc.AttributesLike); ok {
+		var content = component.GetSubcomponent(doc.Symbol("$content"))
+		if uti.IsDefined(content) {
+			// Certificates and other notary types are validated against their types.
+			var violations = ContentClass().Violations(content)
+			var type_ = content.GetConstraint(doc.Symbol("$type"))
+			if uti.IsDefined(type_) {
+				switch doc.FormatComponent(type_) {
+				case "/bali/types/notary/Bundle/v3":
+					violations = BundleClass().Violations(content)
+				case "/bali/types/notary/Identity/v3":
+					violations = IdentityClass().Violations(content)
+				case "/bali/types/notary/Payload/v3":
+					violations = PayloadClass().Violations(content)
+				case "/bali/types/notary/Permissions/v3":
+					violations = PermissionsClass().Violations(content)
+				case "/bali/types/notary/Policy/v3":
+					violations = PolicyClass().Violations(content)
+				case "/bali/types/notary/TreeHead/v3":
+					violations = TreeHeadClass().Violations(content)
+				}
+			}
+			validator.ValidateComponent("$content", violations)
+		}
+		var notaries = component.GetSubcomponent(doc.Symbol("$notaries"))
+		if uti.IsDefined(notaries) {
+			if items, ok := notaries.GetLiteral().(doc.ItemsLike); ok {
+				var index = 1
+				var iterator = items.GetComponents().GetIterator()
+				for iterator.HasNext() {
+					var key = fmt.Sprintf("$notaries[%d]", index)
+					var violations = NotaryClass().Violations(iterator.GetNext())
+					validator.ValidateComponent(key, violations)
+					index++
+				}
+			}
+		}
+	}
+	return validator.GetViolations()
+}
+
 // INSTANCE INTERFACE
 
 // Principal Methods
@@ -74,6 +183,38 @@ func (v *document_) AsSource() string {
 	return doc.FormatComponent(v.Composite) + "\n"
 }
 
+func (v *document_) AsJSON() string {
+	return JSONClass().FormatJSON(v.Composite)
+}
+
+func (v *document_) AsJWS() string {
+	var payload, signature = v.getPayloadSeal(CanonicalClass().JWSFormat())
+	var encoding = b64.RawURLEncoding
+	return encoding.EncodeToString(payload.GetProtected().AsIntrinsic()) + "." +
+		encoding.EncodeToString(payload.GetPayload().AsIntrinsic()) + "." +
+		encoding.EncodeToString(signature)
+}
+
+func (v *document_) AsCOSE() []byte {
+	var payload, signature = v.getPayloadSeal(CanonicalClass().COSEFormat())
+	var protected = payload.GetProtected().AsIntrinsic()
+	var content = payload.GetPayload().AsIntrinsic()
+	var canonical = canonicalClass()
+	var bytes = []byte{0xd2} // The COSE_Sign1 tag.
+	bytes = canonical.encodeHead(bytes, 4, 4)
+	bytes = canonical.encodeHead(bytes, 2, uint64(len(protected)))
+	bytes = append(bytes, protected...)
+	bytes = canonical.encodeHead(bytes, 5, 0) // An empty unprotected header.
+	bytes = canonical.encodeHead(bytes, 2, uint64(len(content)))
+	bytes = append(bytes, content...)
+	bytes = canonical.encodeHead(bytes, 2, uint64(len(signature)))
+	return append(bytes, signature...)
+}
+
+func (v *document_) AsCanonical() []byte {
+	return CanonicalClass().CanonicalBytes(v.Composite)
+}
+
 // Attribute Methods
 
 func (v *document_) GetContent() Parameterized {
@@ -154,10 +295,121 @@ func (v *document_) RemoveNotarySeal() SealLike {
 	return seal
 }
 
+func (v *document_) SetNotaryProof(
+	proof InclusionProofLike,
+) {
+	v.SetSubcomponent(
+		proof.AsIntrinsic(),
+		doc.Symbol("$notaries"),
+		-1, // The last notary seal.
+		doc.Symbol("$proof"),
+	)
+}
+
+func (v *document_) RemoveNotaryProof() InclusionProofLike {
+	var proof InclusionProofLike
+	var component = v.RemoveSubcomponent(
+		doc.Symbol("$notaries"),
+		-1, // The last notary seal.
+		doc.Symbol("$proof"),
+	)
+	if uti.IsDefined(component) {
+		var source = doc.FormatComponent(component)
+		proof = InclusionProofClass().InclusionProofFromSource(source)
+	}
+	return proof
+}
+
 // PROTECTED INTERFACE
 
 // Private Methods
 
+func (c *documentClass_) payloadDocument(
+	protected []byte,
+	payload []byte,
+	signature []byte,
+	format doc.NameLike,
+) DocumentLike {
+	// Wrap the payload in a new document whose tag is derived from the signed
+	// bytes, so that each value in the wrapper is covered by the signature.
+	var digest = sha.Sum512(append(append([]byte{}, protected...), payload...))
+	var entity = PayloadClass().Payload(
+		doc.Binary(protected),
+		doc.Binary(payload),
+		doc.Tag(digest[:20]),
+		doc.Version(),
+		nil,
+	)
+	var document = c.Document(entity)
+
+	// The seal algorithm is determined by the algorithm in the protected
+	// header, and any algorithm that cannot be mapped to one is rejected.
+	var algorithm string
+	switch entity.GetAlgorithm() {
+	case "EdDSA":
+		algorithm = "ED25519"
+	default:
+		var message = fmt.Sprintf(
+			"The protected header specifies an unsupported algorithm: %v",
+			entity.GetAlgorithm(),
+		)
+		panic(message)
+	}
+
+	// The key identifier is a citation to the certificate of the signer and
+	// the timestamp is the time at which the signature was issued.
+	var resource = doc.Resource("<" + entity.GetKeyID() + ">")
+	var citation = CitationClass().CitationFromResource(resource)
+	var notary = NotaryClass().NotaryFromSource(`[
+    $owner: ` + citation.GetTag().AsSource() + `
+    $timestamp: ` + entity.GetTimestamp().AsSource() + `
+    $citation: ` + citation.AsSource() + `
+]($type: /bali/types/notary/Notary/v3)`)
+	document.AddNotary(notary)
+	var seal = SealClass().SealWithFormat(
+		doc.Quote(`"`+algorithm+`"`),
+		doc.Binary(signature),
+		format,
+	)
+	document.SetNotarySeal(seal)
+	return document
+}
+
+func (c *documentClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Document/v3", violations)
+}
+
+func (v *document_) getPayloadSeal(
+	format doc.NameLike,
+) (
+	payload PayloadLike,
+	signature []byte,
+) {
+	var content = v.GetSubcomponent(doc.Symbol("$content"))
+	payload = PayloadClass().PayloadFromSource(doc.FormatComponent(content))
+	var component = v.GetSubcomponent(
+		doc.Symbol("$notaries"),
+		-1, // The last notary seal.
+	)
+	if uti.IsUndefined(component) {
+		panic("The document has not been notarized.")
+	}
+	var notary = NotaryClass().NotaryFromSource(doc.FormatComponent(component))
+	var seal = notary.GetOptionalSeal()
+	if uti.IsUndefined(seal) || uti.IsUndefined(seal.GetOptionalFormat()) ||
+		seal.GetOptionalFormat().AsSource() != format.AsSource() {
+		var message = fmt.Sprintf(
+			"The document is not sealed using the format: %s",
+			format.AsSource(),
+		)
+		panic(message)
+	}
+	return payload, seal.GetSignature().AsIntrinsic()
+}
+
 // Instance Structure
 
 type document_ struct {
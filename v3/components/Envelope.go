@@ -0,0 +1,195 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func EnvelopeClass() EnvelopeClassLike {
+	return envelopeClass()
+}
+
+// Constructor Methods
+
+func (c *envelopeClass_) Envelope(
+	algorithm doc.QuoteLike,
+	ephemeralKey doc.BinaryLike,
+	ciphertext doc.BinaryLike,
+) EnvelopeLike {
+	if uti.IsUndefined(algorithm) {
+		panic("The \"algorithm\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(ephemeralKey) {
+		panic("The \"ephemeralKey\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(ciphertext) {
+		panic("The \"ciphertext\" attribute is required by this class.")
+	}
+
+	var source = `[
+    $algorithm: ` + algorithm.AsSource() + `
+    $ephemeralKey: ` + ephemeralKey.AsSource() + `
+    $recipients: [ ]
+    $ciphertext: ` + ciphertext.AsSource() + `
+]($type: /bali/types/notary/Envelope/v3)`
+	return c.EnvelopeFromSource(source)
+}
+
+func (c *envelopeClass_) EnvelopeFromSource(
+	source string,
+) EnvelopeLike {
+	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
+	var instance = &envelope_{
+		// Initialize the instance attributes.
+
+		// Initialize the inherited aspects.
+		Composite: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *envelopeClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Envelope/v3")
+	validator.ValidateAttribute("$algorithm", QuoteKind, false)
+	validator.ValidateAttribute("$ephemeralKey", BinaryKind, false)
+	validator.ValidateAttribute("$recipients", ItemsKind, false)
+	validator.ValidateAttribute("$ciphertext", BinaryKind, false)
+	return validator.GetViolations()
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *envelope_) GetClass() EnvelopeClassLike {
+	return envelopeClass()
+}
+
+func (v *envelope_) AsIntrinsic() doc.Composite {
+	return v.Composite
+}
+
+func (v *envelope_) AsSource() string {
+	return doc.FormatComponent(v.Composite) + "\n"
+}
+
+// Attribute Methods
+
+func (v *envelope_) GetAlgorithm() doc.QuoteLike {
+	var component = v.GetSubcomponent(doc.Symbol("$algorithm"))
+	return doc.Quote(doc.FormatComponent(component))
+}
+
+func (v *envelope_) GetEphemeralKey() doc.BinaryLike {
+	var component = v.GetSubcomponent(doc.Symbol("$ephemeralKey"))
+	return doc.Binary(doc.FormatComponent(component))
+}
+
+func (v *envelope_) GetCiphertext() doc.BinaryLike {
+	var component = v.GetSubcomponent(doc.Symbol("$ciphertext"))
+	return doc.Binary(doc.FormatComponent(component))
+}
+
+func (v *envelope_) AddRecipient(
+	recipient doc.ResourceLike,
+	wrappedKey doc.BinaryLike,
+) {
+	var source = `[
+    $recipient: ` + recipient.AsSource() + `
+    $wrappedKey: ` + wrappedKey.AsSource() + `
+]`
+	v.SetSubcomponent(
+		doc.ParseComponent(source),
+		doc.Symbol("$recipients"),
+		0,
+	)
+}
+
+func (v *envelope_) GetRecipients() []doc.ResourceLike {
+	var recipients []doc.ResourceLike
+	var component = v.GetSubcomponent(doc.Symbol("$recipients"))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var recipient = iterator.GetNext().GetSubcomponent(doc.Symbol("$recipient"))
+		recipients = append(recipients, doc.Resource(doc.FormatComponent(recipient)))
+	}
+	return recipients
+}
+
+func (v *envelope_) GetWrappedKey(
+	recipient doc.ResourceLike,
+) doc.BinaryLike {
+	var wrappedKey doc.BinaryLike
+	var component = v.GetSubcomponent(doc.Symbol("$recipients"))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var item = iterator.GetNext()
+		var resource = item.GetSubcomponent(doc.Symbol("$recipient"))
+		if doc.FormatComponent(resource) == recipient.AsSource() {
+			var key = item.GetSubcomponent(doc.Symbol("$wrappedKey"))
+			wrappedKey = doc.Binary(doc.FormatComponent(key))
+			break
+		}
+	}
+	return wrappedKey
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *envelopeClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Envelope/v3", violations)
+}
+
+// Instance Structure
+
+type envelope_ struct {
+	// Declare the instance attributes.
+
+	// Declare the inherited aspects.
+	doc.Composite
+}
+
+// Class Structure
+
+type envelopeClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func envelopeClass() *envelopeClass_ {
+	return envelopeClassReference_
+}
+
+var envelopeClassReference_ = &envelopeClass_{
+	// Initialize the class constants.
+}
@@ -73,6 +73,7 @@ func (c *identityClass_) IdentityFromSource(
 	source string,
 ) IdentityLike {
 	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
 	var instance = &identity_{
 		// Initialize the instance attributes.
 
@@ -86,6 +87,41 @@ func (c *identityClass_) IdentityFromSource(
 
 // Function Methods
 
+func (c *identityClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Identity/v3")
+	validator.ValidateParameter("$tag", TagKind, false)
+	validator.ValidateParameter("$version", VersionKind, false)
+	validator.ValidateParameter("$permissions", NameKind, false)
+	validator.ValidateParameter("$previous", ResourceKind, true)
+	validator.ValidateAttribute("$algorithm", QuoteKind, false)
+	validator.ValidateAttribute("$key", BinaryKind, false)
+	validator.ValidateAttribute("$attributes", AnyKind, false)
+	validator.ValidateSize("$key", "ED25519", 32)
+
+	// Any additional attributes must be typed public keys.
+	var attributes, ok = component.GetLiteral().(doc.AttributesLike)
+	if ok {
+		var iterator = attributes.GetAssociations().GetIterator()
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			var name = doc.FormatComponent(association.GetKey())
+			switch name {
+			case "$algorithm", "$key", "$attributes":
+				continue
+			}
+			var key = ValidatorClass().Validator(association.GetValue())
+			key.ValidateAttribute("$algorithm", QuoteKind, false)
+			key.ValidateAttribute("$key", BinaryKind, false)
+			key.ValidateSize("$key", "X25519", 32)
+			validator.ValidateComponent(name, key.GetViolations())
+		}
+	}
+	return validator.GetViolations()
+}
+
 // INSTANCE INTERFACE
 
 // Principal Methods
@@ -119,6 +155,45 @@ func (v *identity_) GetAttributes() doc.Composite {
 	return component
 }
 
+func (v *identity_) AddPublicKey(
+	name string,
+	algorithm doc.QuoteLike,
+	key doc.BinaryLike,
+) {
+	var source = `[
+    $algorithm: ` + algorithm.AsSource() + `
+    $key: ` + key.AsSource() + `
+]`
+	v.SetSubcomponent(
+		doc.ParseComponent(source),
+		doc.Symbol(name),
+	)
+}
+
+func (v *identity_) GetOptionalKeyAlgorithm(
+	name string,
+) doc.QuoteLike {
+	var algorithm doc.QuoteLike
+	var component = v.GetSubcomponent(doc.Symbol(name))
+	if uti.IsDefined(component) {
+		component = component.GetSubcomponent(doc.Symbol("$algorithm"))
+		algorithm = doc.Quote(doc.FormatComponent(component))
+	}
+	return algorithm
+}
+
+func (v *identity_) GetOptionalPublicKey(
+	name string,
+) doc.BinaryLike {
+	var key doc.BinaryLike
+	var component = v.GetSubcomponent(doc.Symbol(name))
+	if uti.IsDefined(component) {
+		component = component.GetSubcomponent(doc.Symbol("$key"))
+		key = doc.Binary(doc.FormatComponent(component))
+	}
+	return key
+}
+
 // Parameterized Methods
 
 func (v *identity_) GetType() doc.NameLike {
@@ -153,8 +228,17 @@ func (v *identity_) GetOptionalPrevious() doc.ResourceLike {
 	return previous
 }
 
+// PROTECTED INTERFACE
+
 // Private Methods
 
+func (c *identityClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Identity/v3", violations)
+}
+
 // Instance Structure
 
 type identity_ struct {
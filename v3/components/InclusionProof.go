@@ -0,0 +1,176 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	stc "strconv"
+	sts "strings"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func InclusionProofClass() InclusionProofClassLike {
+	return inclusionProofClass()
+}
+
+// Constructor Methods
+
+func (c *inclusionProofClass_) InclusionProof(
+	algorithm doc.QuoteLike,
+	index uint,
+	size uint,
+	path []doc.BinaryLike,
+	treeHead doc.ResourceLike,
+) InclusionProofLike {
+	if uti.IsUndefined(algorithm) {
+		panic("The \"algorithm\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(treeHead) {
+		panic("The \"treeHead\" attribute is required by this class.")
+	}
+
+	var hashes []string
+	for _, hash := range path {
+		hashes = append(hashes, hash.AsSource())
+	}
+	var source = `[
+    $algorithm: ` + algorithm.AsSource() + `
+    $index: ` + stc.Itoa(int(index)) + `
+    $size: ` + stc.Itoa(int(size)) + `
+    $path: [ ` + sts.Join(hashes, " ") + ` ]
+    $treeHead: ` + treeHead.AsSource() + `
+]($type: /bali/types/notary/InclusionProof/v3)`
+	return c.InclusionProofFromSource(source)
+}
+
+func (c *inclusionProofClass_) InclusionProofFromSource(
+	source string,
+) InclusionProofLike {
+	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
+	var instance = &inclusionProof_{
+		// Initialize the instance attributes.
+
+		// Initialize the inherited aspects.
+		Composite: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *inclusionProofClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/InclusionProof/v3")
+	validator.ValidateAttribute("$algorithm", QuoteKind, false)
+	validator.ValidateAttribute("$index", NumberKind, false)
+	validator.ValidateAttribute("$size", NumberKind, false)
+	validator.ValidateAttribute("$path", ItemsKind, false)
+	validator.ValidateAttribute("$treeHead", ResourceKind, false)
+	return validator.GetViolations()
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *inclusionProof_) GetClass() InclusionProofClassLike {
+	return inclusionProofClass()
+}
+
+func (v *inclusionProof_) AsIntrinsic() doc.Composite {
+	return v.Composite
+}
+
+func (v *inclusionProof_) AsSource() string {
+	return doc.FormatComponent(v.Composite) + "\n"
+}
+
+// Attribute Methods
+
+func (v *inclusionProof_) GetAlgorithm() doc.QuoteLike {
+	var component = v.GetSubcomponent(doc.Symbol("$algorithm"))
+	return doc.Quote(doc.FormatComponent(component))
+}
+
+func (v *inclusionProof_) GetIndex() uint {
+	var component = v.GetSubcomponent(doc.Symbol("$index"))
+	var index, _ = stc.Atoi(doc.FormatComponent(component))
+	return uint(index)
+}
+
+func (v *inclusionProof_) GetSize() uint {
+	var component = v.GetSubcomponent(doc.Symbol("$size"))
+	var size, _ = stc.Atoi(doc.FormatComponent(component))
+	return uint(size)
+}
+
+func (v *inclusionProof_) GetPath() []doc.BinaryLike {
+	var path []doc.BinaryLike
+	var component = v.GetSubcomponent(doc.Symbol("$path"))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var hash = iterator.GetNext()
+		path = append(path, doc.Binary(doc.FormatComponent(hash)))
+	}
+	return path
+}
+
+func (v *inclusionProof_) GetTreeHead() doc.ResourceLike {
+	var component = v.GetSubcomponent(doc.Symbol("$treeHead"))
+	return doc.Resource(doc.FormatComponent(component))
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *inclusionProofClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/InclusionProof/v3", violations)
+}
+
+// Instance Structure
+
+type inclusionProof_ struct {
+	// Declare the instance attributes.
+
+	// Declare the inherited aspects.
+	doc.Composite
+}
+
+// Class Structure
+
+type inclusionProofClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func inclusionProofClass() *inclusionProofClass_ {
+	return inclusionProofClassReference_
+}
+
+var inclusionProofClassReference_ = &inclusionProofClass_{
+	// Initialize the class constants.
+}
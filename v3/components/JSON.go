@@ -0,0 +1,386 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	byt "bytes"
+	b64 "encoding/base64"
+	jsn "encoding/json"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	iox "io"
+	sts "strings"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func JSONClass() JSONClassLike {
+	return jsonClass()
+}
+
+// Constructor Methods
+
+// Constant Methods
+
+// Function Methods
+
+func (c *jsonClass_) FormatJSON(
+	component doc.Composite,
+) string {
+	if uti.IsUndefined(component) {
+		panic("The \"component\" attribute is required by this function.")
+	}
+	var builder sts.Builder
+	c.formatComponent(&builder, component, 0)
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+func (c *jsonClass_) ParseJSON(
+	source string,
+) doc.Composite {
+	var decoder = jsn.NewDecoder(sts.NewReader(source))
+	decoder.UseNumber()
+	var bali = c.parseValue(decoder, c.nextToken(decoder))
+	if _, err := decoder.Token(); err != iox.EOF {
+		panic("The JSON source contains more than one value.")
+	}
+	return doc.ParseComponent(bali)
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *jsonClass_) formatComponent(
+	builder *sts.Builder,
+	component doc.Composite,
+	depth int,
+) {
+	// Components without parameters are formatted as their literal values.
+	var parameters = component.GetOptionalParameters()
+	if uti.IsUndefined(parameters) {
+		c.formatLiteral(builder, component.GetLiteral(), depth)
+		return
+	}
+
+	// Otherwise the literal value and its parameters are formatted together.
+	var indentation = sts.Repeat(c.indentation_, depth+1)
+	builder.WriteString("{\n" + indentation + c.formatString(c.value_) + ": ")
+	c.formatLiteral(builder, component.GetLiteral(), depth+1)
+	builder.WriteString(",\n" + indentation + c.formatString(c.parameters_) + ": {")
+	var iterator = parameters.GetConstraints().GetIterator()
+	for iterator.HasNext() {
+		var association = iterator.GetNext()
+		builder.WriteString("\n" + indentation + c.indentation_)
+		builder.WriteString(c.formatString(association.GetKey().AsSource()) + ": ")
+		c.formatComponent(builder, association.GetValue(), depth+2)
+		if iterator.HasNext() {
+			builder.WriteString(",")
+		}
+	}
+	builder.WriteString("\n" + indentation + "}\n" + sts.Repeat(c.indentation_, depth) + "}")
+}
+
+func (c *jsonClass_) formatLiteral(
+	builder *sts.Builder,
+	literal any,
+	depth int,
+) {
+	var indentation = sts.Repeat(c.indentation_, depth)
+	switch actual := literal.(type) {
+	case doc.ItemsLike:
+		// Lists are formatted as arrays.
+		var iterator = actual.GetComponents().GetIterator()
+		if !iterator.HasNext() {
+			builder.WriteString("[]")
+			return
+		}
+		builder.WriteString("[")
+		for iterator.HasNext() {
+			builder.WriteString("\n" + indentation + c.indentation_)
+			c.formatComponent(builder, iterator.GetNext(), depth+1)
+			if iterator.HasNext() {
+				builder.WriteString(",")
+			}
+		}
+		builder.WriteString("\n" + indentation + "]")
+	case doc.AttributesLike:
+		// Catalogs are formatted as objects with their keys in order.
+		var iterator = actual.GetAssociations().GetIterator()
+		if !iterator.HasNext() {
+			builder.WriteString("{}")
+			return
+		}
+		builder.WriteString("{")
+		for iterator.HasNext() {
+			var association = iterator.GetNext()
+			builder.WriteString("\n" + indentation + c.indentation_)
+			var key sts.Builder
+			c.formatLiteral(&key, association.GetKey(), depth+1)
+			var name = key.String()
+			if !sts.HasPrefix(name, `"`) {
+				name = c.formatString(name) // JSON keys must be strings.
+			}
+			builder.WriteString(name + ": ")
+			c.formatComponent(builder, association.GetValue(), depth+1)
+			if iterator.HasNext() {
+				builder.WriteString(",")
+			}
+		}
+		builder.WriteString("\n" + indentation + "}")
+	case doc.BooleanLike:
+		builder.WriteString(actual.AsSource())
+	case doc.BinaryLike:
+		// Binary values are formatted without any line breaks.
+		var encoded = b64.StdEncoding.EncodeToString(actual.AsIntrinsic())
+		builder.WriteString(c.formatString("'" + encoded + "'"))
+	case interface{ AsSource() string }:
+		// All other primitive values are formatted as their Bali source, with
+		// narratives formatted the same way at any depth.
+		var source = actual.AsSource()
+		if sts.HasPrefix(source, `">`) {
+			source = c.normalizeNarrative(source)
+		}
+		builder.WriteString(c.formatString(source))
+	default:
+		// Procedural literals are formatted as their Bali source.
+		builder.WriteString(c.formatString(doc.FormatComponent(actual)))
+	}
+}
+
+func (c *jsonClass_) formatString(
+	value string,
+) string {
+	var buffer byt.Buffer
+	var encoder = jsn.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		panic(err)
+	}
+	return sts.TrimSuffix(buffer.String(), "\n")
+}
+
+func (c *jsonClass_) nextToken(
+	decoder *jsn.Decoder,
+) jsn.Token {
+	var token, err = decoder.Token()
+	if err != nil {
+		var message = fmt.Sprintf(
+			"The JSON source is invalid: %v",
+			err,
+		)
+		panic(message)
+	}
+	return token
+}
+
+func (c *jsonClass_) normalizeNarrative(
+	source string,
+) string {
+	// The lines of a narrative are indented to match the depth at which it is
+	// formatted, so the common indentation is removed.
+	var lines = sts.Split(source, "\n")
+	var indentation = -1
+	for _, line := range lines[1:] {
+		var trimmed = sts.TrimLeft(line, " ")
+		if len(trimmed) > 0 {
+			var length = len(line) - len(trimmed)
+			if indentation < 0 || length < indentation {
+				indentation = length
+			}
+		}
+	}
+	if indentation < 0 {
+		return source
+	}
+	for index, line := range lines[1:] {
+		lines[index+1] = line[min(indentation, len(line)):]
+	}
+	return sts.Join(lines, "\n")
+}
+
+func (c *jsonClass_) parseObject(
+	decoder *jsn.Decoder,
+) string {
+	// Read each key and its value in order.
+	var keys []string
+	var values []string
+	var literal string
+	var parameters string
+	var isComponent bool
+	for decoder.More() {
+		var key = c.nextToken(decoder).(string)
+		switch key {
+		case c.value_:
+			literal = c.parseValue(decoder, c.nextToken(decoder))
+			isComponent = true
+		case c.parameters_:
+			parameters = c.parseParameters(decoder)
+			isComponent = true
+		default:
+			keys = append(keys, c.parseString(key))
+			values = append(values, c.parseValue(decoder, c.nextToken(decoder)))
+		}
+	}
+	c.nextToken(decoder) // The closing brace.
+
+	// A component with parameters.
+	if isComponent {
+		if len(keys) > 0 || len(literal) == 0 || len(parameters) == 0 {
+			var message = fmt.Sprintf(
+				"A component object must contain only %q and %q.",
+				c.value_,
+				c.parameters_,
+			)
+			panic(message)
+		}
+		return literal + parameters
+	}
+
+	// A catalog.
+	if len(keys) == 0 {
+		return "[:]"
+	}
+	var bali = "[\n"
+	for index, key := range keys {
+		bali += key + ": " + values[index] + "\n"
+	}
+	return bali + "]"
+}
+
+func (c *jsonClass_) parseParameters(
+	decoder *jsn.Decoder,
+) string {
+	if c.nextToken(decoder) != jsn.Delim('{') {
+		panic("The parameters of a component must be an object.")
+	}
+	var bali = "(\n"
+	for decoder.More() {
+		var key = c.parseString(c.nextToken(decoder).(string))
+		var value = c.parseValue(decoder, c.nextToken(decoder))
+		bali += key + ": " + value + "\n"
+	}
+	c.nextToken(decoder) // The closing brace.
+	return bali + ")"
+}
+
+func (c *jsonClass_) parseString(
+	value string,
+) string {
+	// Binary values are converted back into their Bali source.
+	if len(value) > 1 && sts.HasPrefix(value, "'") && sts.HasSuffix(value, "'") {
+		var bytes, err = b64.StdEncoding.DecodeString(value[1 : len(value)-1])
+		if err != nil {
+			var message = fmt.Sprintf(
+				"An invalid binary value was found in the JSON source: %s",
+				value,
+			)
+			panic(message)
+		}
+		return doc.Binary(bytes).AsSource()
+	}
+
+	// Any other string must be the source of exactly one primitive value so
+	// that it cannot change the structure of the resulting component.
+	var component = c.parsePrimitive(value)
+	var _, isPrimitive = component.GetLiteral().(interface{ AsSource() string })
+	if !isPrimitive || uti.IsDefined(component.GetOptionalParameters()) {
+		var message = fmt.Sprintf(
+			"A JSON string must contain a single primitive value: %q",
+			value,
+		)
+		panic(message)
+	}
+	return value
+}
+
+func (c *jsonClass_) parsePrimitive(
+	value string,
+) (
+	component doc.Composite,
+) {
+	// Any string that is not valid Bali source is reported as such.
+	defer func() {
+		if e := recover(); e != nil {
+			var message = fmt.Sprintf(
+				"A JSON string must contain a single primitive value: %q",
+				value,
+			)
+			panic(message)
+		}
+	}()
+	component = doc.ParseComponent(value)
+	return
+}
+
+func (c *jsonClass_) parseValue(
+	decoder *jsn.Decoder,
+	token jsn.Token,
+) string {
+	switch actual := token.(type) {
+	case jsn.Delim:
+		switch actual {
+		case '{':
+			return c.parseObject(decoder)
+		case '[':
+			var bali = "[\n"
+			var isEmpty = true
+			for decoder.More() {
+				bali += c.parseValue(decoder, c.nextToken(decoder)) + "\n"
+				isEmpty = false
+			}
+			c.nextToken(decoder) // The closing bracket.
+			if isEmpty {
+				return "[ ]"
+			}
+			return bali + "]"
+		}
+	case string:
+		return c.parseString(actual)
+	case bool:
+		return fmt.Sprintf("%t", actual)
+	case jsn.Number:
+		return actual.String()
+	}
+	var message = fmt.Sprintf(
+		"An unsupported JSON value was found: %v",
+		token,
+	)
+	panic(message)
+}
+
+// Class Structure
+
+type jsonClass_ struct {
+	// Declare the class constants.
+	indentation_ string
+	value_       string
+	parameters_  string
+}
+
+// Class Reference
+
+func jsonClass() *jsonClass_ {
+	return jsonClassReference_
+}
+
+var jsonClassReference_ = &jsonClass_{
+	// Initialize the class constants.
+	indentation_: "    ",
+	value_:       "@value",
+	parameters_:  "@parameters",
+}
@@ -52,6 +52,7 @@ func (c *notaryClass_) NotaryFromSource(
 	source string,
 ) NotaryLike {
 	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
 	var instance = &notary_{
 		// Initialize the instance attributes.
 
@@ -65,6 +66,41 @@ func (c *notaryClass_) NotaryFromSource(
 
 // Function Methods
 
+func (c *notaryClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Notary/v3")
+	validator.ValidateAttribute("$owner", TagKind, false)
+	validator.ValidateAttribute("$timestamp", MomentKind, false)
+	validator.ValidateAttribute("$citation", AttributesKind, true)
+	if _, ok := component.GetLiteral().(doc.AttributesLike); ok {
+		// The citation is "none" for a self-signed document.
+		var citation = component.GetSubcomponent(doc.Symbol("$citation"))
+		if uti.IsDefined(citation) {
+			if _, ok := citation.GetLiteral().(doc.AttributesLike); ok {
+				var violations = CitationClass().Violations(citation)
+				validator.ValidateComponent("$citation", violations)
+			}
+		}
+
+		// The seal is not added until the document has been signed.
+		var seal = component.GetSubcomponent(doc.Symbol("$seal"))
+		if uti.IsDefined(seal) {
+			var violations = SealClass().Violations(seal)
+			validator.ValidateComponent("$seal", violations)
+		}
+
+		// A transparency log proof may be added after the document is sealed.
+		var proof = component.GetSubcomponent(doc.Symbol("$proof"))
+		if uti.IsDefined(proof) {
+			var violations = InclusionProofClass().Violations(proof)
+			validator.ValidateComponent("$proof", violations)
+		}
+	}
+	return validator.GetViolations()
+}
+
 // INSTANCE INTERFACE
 
 // Principal Methods
@@ -115,10 +151,27 @@ func (v *notary_) GetOptionalSeal() SealLike {
 	return seal
 }
 
+func (v *notary_) GetOptionalProof() InclusionProofLike {
+	var proof InclusionProofLike
+	var component = v.GetSubcomponent(doc.Symbol("$proof"))
+	if uti.IsDefined(component) {
+		var source = doc.FormatComponent(component)
+		proof = InclusionProofClass().InclusionProofFromSource(source)
+	}
+	return proof
+}
+
 // PROTECTED INTERFACE
 
 // Private Methods
 
+func (c *notaryClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Notary/v3", violations)
+}
+
 // Instance Structure
 
 type notary_ struct {
@@ -0,0 +1,364 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	jsn "encoding/json"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	stc "strconv"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func PayloadClass() PayloadClassLike {
+	return payloadClass()
+}
+
+// Constructor Methods
+
+func (c *payloadClass_) Payload(
+	protected doc.BinaryLike,
+	payload doc.BinaryLike,
+	tag doc.TagLike,
+	version doc.VersionLike,
+	optionalPrevious doc.ResourceLike,
+) PayloadLike {
+	if uti.IsUndefined(protected) {
+		panic("The \"protected\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(payload) {
+		panic("The \"payload\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(tag) {
+		panic("The \"tag\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(version) {
+		panic("The \"version\" attribute is required by this class.")
+	}
+
+	var previous = "none"
+	if uti.IsDefined(optionalPrevious) {
+		previous = optionalPrevious.AsSource()
+	}
+	var source = `[
+    $protected: ` + protected.AsSource() + `
+    $payload: ` + payload.AsSource() + `
+](
+    $type: /bali/types/notary/Payload/v3
+    $tag: ` + tag.AsSource() + `
+    $version: ` + version.AsSource() + `
+    $permissions: /bali/permissions/Public/v3
+    $previous: ` + previous + `
+)`
+	return c.PayloadFromSource(source)
+}
+
+func (c *payloadClass_) PayloadFromSource(
+	source string,
+) PayloadLike {
+	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
+	var instance = &payload_{
+		// Initialize the instance attributes.
+
+		// Initialize the inherited aspects.
+		Composite: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *payloadClass_) ProtectedHeader(
+	format doc.NameLike,
+	keyID string,
+	timestamp doc.MomentLike,
+) doc.BinaryLike {
+	if uti.IsUndefined(timestamp) {
+		panic("The \"timestamp\" attribute is required by this function.")
+	}
+	var milliseconds = timestamp.AsIntrinsic()
+	if milliseconds < 0 || milliseconds%1000 != 0 {
+		panic("The timestamp of a protected header must be in whole seconds.")
+	}
+	var issued = uint64(milliseconds / 1000)
+	var canonical = canonicalClass()
+	switch format.AsSource() {
+	case canonical.jwsFormat_:
+		var header = map[string]any{
+			"alg": c.algorithm_,
+			"cty": c.contentType_,
+			"iat": issued,
+			"kid": keyID,
+		}
+		var bytes, err = jsn.Marshal(header)
+		if err != nil {
+			panic(err)
+		}
+		return doc.Binary(bytes)
+	case canonical.coseFormat_:
+		// The header labels are encoded in ascending order.  The timestamp is
+		// the "iat" claim (6) of the CWT claims (15) defined in RFC 9597.
+		var bytes = canonical.encodeHead(nil, 5, 4)
+		bytes = append(bytes, 0x01, 0x27) // The EdDSA algorithm.
+		bytes = append(bytes, 0x03)
+		bytes = canonical.encodeHead(bytes, 3, uint64(len(c.contentType_)))
+		bytes = append(bytes, c.contentType_...)
+		bytes = append(bytes, 0x04)
+		bytes = canonical.encodeHead(bytes, 2, uint64(len(keyID)))
+		bytes = append(bytes, keyID...)
+		bytes = append(bytes, 0x0f)
+		bytes = canonical.encodeHead(bytes, 5, 1)
+		bytes = append(bytes, 0x06)
+		bytes = canonical.encodeHead(bytes, 0, issued)
+		return doc.Binary(bytes)
+	default:
+		var message = fmt.Sprintf(
+			"A protected header is not defined for the format: %s",
+			format.AsSource(),
+		)
+		panic(message)
+	}
+}
+
+func (c *payloadClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Payload/v3")
+	validator.ValidateParameter("$tag", TagKind, false)
+	validator.ValidateParameter("$version", VersionKind, false)
+	validator.ValidateParameter("$permissions", NameKind, false)
+	validator.ValidateParameter("$previous", ResourceKind, true)
+	validator.ValidateAttribute("$protected", BinaryKind, false)
+	validator.ValidateAttribute("$payload", BinaryKind, false)
+	return validator.GetViolations()
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *payload_) GetClass() PayloadClassLike {
+	return payloadClass()
+}
+
+func (v *payload_) AsIntrinsic() doc.Composite {
+	return v.Composite
+}
+
+func (v *payload_) AsSource() string {
+	return doc.FormatComponent(v.Composite) + "\n"
+}
+
+// Attribute Methods
+
+func (v *payload_) GetProtected() doc.BinaryLike {
+	var component = v.GetSubcomponent(doc.Symbol("$protected"))
+	return doc.Binary(doc.FormatComponent(component))
+}
+
+func (v *payload_) GetPayload() doc.BinaryLike {
+	var component = v.GetSubcomponent(doc.Symbol("$payload"))
+	return doc.Binary(doc.FormatComponent(component))
+}
+
+func (v *payload_) GetAlgorithm() string {
+	var algorithm, _, _ = v.parseHeader()
+	if len(algorithm) == 0 {
+		panic("The protected header does not contain an algorithm.")
+	}
+	return algorithm
+}
+
+func (v *payload_) GetKeyID() string {
+	var algorithm, keyID, _ = v.parseHeader()
+	if algorithm != payloadClass().algorithm_ {
+		var message = fmt.Sprintf(
+			"The protected header specifies an unsupported algorithm: %v",
+			algorithm,
+		)
+		panic(message)
+	}
+	if len(keyID) == 0 {
+		panic("The protected header does not contain a key identifier.")
+	}
+	return keyID
+}
+
+func (v *payload_) GetTimestamp() doc.MomentLike {
+	var _, _, timestamp = v.parseHeader()
+	if uti.IsUndefined(timestamp) {
+		panic("The protected header does not contain a timestamp.")
+	}
+	return timestamp
+}
+
+// Parameterized Methods
+
+func (v *payload_) GetType() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$type"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *payload_) GetTag() doc.TagLike {
+	var component = v.GetConstraint(doc.Symbol("$tag"))
+	return doc.Tag(doc.FormatComponent(component))
+}
+
+func (v *payload_) GetVersion() doc.VersionLike {
+	var component = v.GetConstraint(doc.Symbol("$version"))
+	return doc.Version(doc.FormatComponent(component))
+}
+
+func (v *payload_) GetPermissions() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$permissions"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *payload_) GetOptionalPrevious() doc.ResourceLike {
+	var previous doc.ResourceLike
+	var component = v.GetConstraint(doc.Symbol("$previous"))
+	if uti.IsDefined(component) {
+		var source = doc.FormatComponent(component)
+		if source != "none" {
+			previous = doc.Resource(source)
+		}
+	}
+	return previous
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *payload_) parseHeader() (
+	algorithm string,
+	keyID string,
+	timestamp doc.MomentLike,
+) {
+	var bytes = v.GetProtected().AsIntrinsic()
+
+	// A JWS header is a JSON object.
+	if len(bytes) > 0 && bytes[0] == '{' {
+		var header struct {
+			Algorithm string  `json:"alg"`
+			KeyID     string  `json:"kid"`
+			Issued    *uint64 `json:"iat"`
+		}
+		if err := jsn.Unmarshal(bytes, &header); err != nil {
+			panic(err)
+		}
+		if header.Issued != nil {
+			timestamp = doc.Moment(int(*header.Issued) * 1000)
+		}
+		return header.Algorithm, header.KeyID, timestamp
+	}
+
+	// A COSE header is a CBOR map with integer labels.
+	var canonical = canonicalClass()
+	var major, count, next = canonical.decodeHead(bytes, 0)
+	if major != 5 {
+		panic("The protected header is not a CBOR map.")
+	}
+	for range count {
+		var label uint64
+		major, label, next = canonical.decodeHead(bytes, next)
+		if major != 0 {
+			panic("The protected header contains an unsupported label.")
+		}
+		switch label {
+		case 1:
+			// The COSE identifier for the EdDSA algorithm is -8.
+			var value uint64
+			major, value, next = canonical.decodeHead(bytes, next)
+			switch {
+			case major == 1 && value == 7:
+				algorithm = payloadClass().algorithm_
+			case major == 1:
+				algorithm = stc.Itoa(-1 - int(value))
+			case major == 0:
+				algorithm = stc.Itoa(int(value))
+			default:
+				panic("The protected header contains an unsupported algorithm.")
+			}
+		case 3:
+			_, next = canonical.decodeString(bytes, next, 3)
+		case 4:
+			var value []byte
+			value, next = canonical.decodeString(bytes, next, 2)
+			keyID = string(value)
+		case 15:
+			// The only supported CWT claim is the "iat" claim (6).
+			var issued uint64
+			major, count, next = canonical.decodeHead(bytes, next)
+			if major != 5 || count != 1 {
+				panic("The protected header contains unsupported claims.")
+			}
+			major, label, next = canonical.decodeHead(bytes, next)
+			if major != 0 || label != 6 {
+				panic("The protected header contains unsupported claims.")
+			}
+			major, issued, next = canonical.decodeHead(bytes, next)
+			if major != 0 {
+				panic("The protected header contains unsupported claims.")
+			}
+			timestamp = doc.Moment(int(issued) * 1000)
+		default:
+			panic("The protected header contains an unsupported label.")
+		}
+	}
+	return algorithm, keyID, timestamp
+}
+
+func (c *payloadClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Payload/v3", violations)
+}
+
+// Instance Structure
+
+type payload_ struct {
+	// Declare the instance attributes.
+
+	// Declare the inherited aspects.
+	doc.Composite
+}
+
+// Class Structure
+
+type payloadClass_ struct {
+	// Declare the class constants.
+	algorithm_   string
+	contentType_ string
+}
+
+// Class Reference
+
+func payloadClass() *payloadClass_ {
+	return payloadClassReference_
+}
+
+var payloadClassReference_ = &payloadClass_{
+	// Initialize the class constants.
+	algorithm_:   "EdDSA",
+	contentType_: "application/bali",
+}
@@ -0,0 +1,256 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func PermissionsClass() PermissionsClassLike {
+	return permissionsClass()
+}
+
+// Constructor Methods
+
+func (c *permissionsClass_) Permissions(
+	name doc.NameLike,
+	tag doc.TagLike,
+	version doc.VersionLike,
+	optionalPrevious doc.ResourceLike,
+) PermissionsLike {
+	if uti.IsUndefined(name) {
+		panic("The \"name\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(tag) {
+		panic("The \"tag\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(version) {
+		panic("The \"version\" attribute is required by this class.")
+	}
+
+	var previous = "none"
+	if uti.IsDefined(optionalPrevious) {
+		previous = optionalPrevious.AsSource()
+	}
+	var source = `[
+    $name: ` + name.AsSource() + `
+    $read: [ ]
+    $write: [ ]
+    $notarize: [ ]
+](
+    $type: /bali/types/notary/Permissions/v3
+    $tag: ` + tag.AsSource() + `
+    $version: ` + version.AsSource() + `
+    $permissions: /bali/permissions/Public/v3
+    $previous: ` + previous + `
+)`
+	return c.PermissionsFromSource(source)
+}
+
+func (c *permissionsClass_) PermissionsFromSource(
+	source string,
+) PermissionsLike {
+	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
+	var instance = &permissions_{
+		// Initialize the instance attributes.
+
+		// Initialize the inherited aspects.
+		Composite: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+func (c *permissionsClass_) Public() doc.NameLike {
+	return c.public_
+}
+
+func (c *permissionsClass_) Private() doc.NameLike {
+	return c.private_
+}
+
+// Function Methods
+
+func (c *permissionsClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Permissions/v3")
+	validator.ValidateParameter("$tag", TagKind, false)
+	validator.ValidateParameter("$version", VersionKind, false)
+	validator.ValidateParameter("$permissions", NameKind, false)
+	validator.ValidateParameter("$previous", ResourceKind, true)
+	validator.ValidateAttribute("$name", NameKind, false)
+	for _, action := range c.actions_ {
+		validator.ValidateAttribute(action, ItemsKind, false)
+	}
+	return validator.GetViolations()
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *permissions_) GetClass() PermissionsClassLike {
+	return permissionsClass()
+}
+
+func (v *permissions_) AsIntrinsic() doc.Composite {
+	return v.Composite
+}
+
+func (v *permissions_) AsSource() string {
+	return doc.FormatComponent(v.Composite) + "\n"
+}
+
+// Attribute Methods
+
+func (v *permissions_) GetName() doc.NameLike {
+	var component = v.GetSubcomponent(doc.Symbol("$name"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *permissions_) GrantAccess(
+	action string,
+	certificate doc.TagLike,
+) {
+	v.checkAction(action)
+	if v.IsGranted(action, certificate) {
+		return
+	}
+	v.SetSubcomponent(
+		doc.ParseComponent(certificate.AsSource()),
+		doc.Symbol(action),
+		0,
+	)
+}
+
+func (v *permissions_) GetGrants(
+	action string,
+) []doc.TagLike {
+	v.checkAction(action)
+	var grants []doc.TagLike
+	var component = v.GetSubcomponent(doc.Symbol(action))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var grant = iterator.GetNext()
+		grants = append(grants, doc.Tag(doc.FormatComponent(grant)))
+	}
+	return grants
+}
+
+func (v *permissions_) IsGranted(
+	action string,
+	certificate doc.TagLike,
+) bool {
+	for _, grant := range v.GetGrants(action) {
+		if grant.AsSource() == certificate.AsSource() {
+			return true
+		}
+	}
+	return false
+}
+
+// Parameterized Methods
+
+func (v *permissions_) GetType() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$type"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *permissions_) GetTag() doc.TagLike {
+	var component = v.GetConstraint(doc.Symbol("$tag"))
+	return doc.Tag(doc.FormatComponent(component))
+}
+
+func (v *permissions_) GetVersion() doc.VersionLike {
+	var component = v.GetConstraint(doc.Symbol("$version"))
+	return doc.Version(doc.FormatComponent(component))
+}
+
+func (v *permissions_) GetPermissions() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$permissions"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *permissions_) GetOptionalPrevious() doc.ResourceLike {
+	var previous doc.ResourceLike
+	var component = v.GetConstraint(doc.Symbol("$previous"))
+	if uti.IsDefined(component) {
+		var source = doc.FormatComponent(component)
+		if source != "none" {
+			previous = doc.Resource(source)
+		}
+	}
+	return previous
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *permissionsClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Permissions/v3", violations)
+}
+
+func (v *permissions_) checkAction(
+	action string,
+) {
+	for _, candidate := range permissionsClass().actions_ {
+		if action == candidate {
+			return
+		}
+	}
+	panic("The action must be one of $read, $write or $notarize: " + action)
+}
+
+// Instance Structure
+
+type permissions_ struct {
+	// Declare the instance attributes.
+
+	// Declare the inherited aspects.
+	doc.Composite
+}
+
+// Class Structure
+
+type permissionsClass_ struct {
+	// Declare the class constants.
+	public_  doc.NameLike
+	private_ doc.NameLike
+	actions_ []string
+}
+
+// Class Reference
+
+func permissionsClass() *permissionsClass_ {
+	return permissionsClassReference_
+}
+
+var permissionsClassReference_ = &permissionsClass_{
+	// Initialize the class constants.
+	public_:  doc.Name("/bali/permissions/Public/v3"),
+	private_: doc.Name("/bali/permissions/Private/v3"),
+	actions_: []string{"$read", "$write", "$notarize"},
+}
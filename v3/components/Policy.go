@@ -0,0 +1,308 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	sts "strings"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func PolicyClass() PolicyClassLike {
+	return policyClass()
+}
+
+// Constructor Methods
+
+func (c *policyClass_) Policy(
+	tag doc.TagLike,
+	version doc.VersionLike,
+	optionalPrevious doc.ResourceLike,
+) PolicyLike {
+	if uti.IsUndefined(tag) {
+		panic("The \"tag\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(version) {
+		panic("The \"version\" attribute is required by this class.")
+	}
+
+	var previous = "none"
+	if uti.IsDefined(optionalPrevious) {
+		previous = optionalPrevious.AsSource()
+	}
+	var source = `[
+    $rules: [ ]
+](
+    $type: /bali/types/notary/Policy/v3
+    $tag: ` + tag.AsSource() + `
+    $version: ` + version.AsSource() + `
+    $permissions: /bali/permissions/Public/v3
+    $previous: ` + previous + `
+)`
+	return c.PolicyFromSource(source)
+}
+
+func (c *policyClass_) PolicyFromSource(
+	source string,
+) PolicyLike {
+	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
+	var instance = &policy_{
+		// Initialize the instance attributes.
+
+		// Initialize the inherited aspects.
+		Composite: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *policyClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Policy/v3")
+	validator.ValidateParameter("$tag", TagKind, false)
+	validator.ValidateParameter("$version", VersionKind, false)
+	validator.ValidateParameter("$permissions", NameKind, false)
+	validator.ValidateParameter("$previous", ResourceKind, true)
+	validator.ValidateAttribute("$rules", ItemsKind, false)
+
+	// Each rule applies to a single type of document.
+	var _, ok = component.GetLiteral().(doc.AttributesLike)
+	if !ok {
+		return validator.GetViolations()
+	}
+	var rules = component.GetSubcomponent(doc.Symbol("$rules"))
+	if uti.IsUndefined(rules) {
+		return validator.GetViolations()
+	}
+	if items, ok := rules.GetLiteral().(doc.ItemsLike); ok {
+		var index = 1
+		var iterator = items.GetComponents().GetIterator()
+		for iterator.HasNext() {
+			var rule = ValidatorClass().Validator(iterator.GetNext())
+			rule.ValidateAttribute("$type", NameKind, false)
+			rule.ValidateAttribute("$notaries", ItemsKind, false)
+			rule.ValidateAttribute("$maximumAge", DurationKind, true)
+			rule.ValidateAttribute("$minimumVersion", VersionKind, true)
+			var key = fmt.Sprintf("$rules[%d]", index)
+			validator.ValidateComponent(key, rule.GetViolations())
+			index++
+		}
+	}
+	return validator.GetViolations()
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *policy_) GetClass() PolicyClassLike {
+	return policyClass()
+}
+
+func (v *policy_) AsIntrinsic() doc.Composite {
+	return v.Composite
+}
+
+func (v *policy_) AsSource() string {
+	return doc.FormatComponent(v.Composite) + "\n"
+}
+
+// Attribute Methods
+
+func (v *policy_) AddRule(
+	type_ doc.NameLike,
+	notaries []doc.TagLike,
+	optionalMaximumAge doc.DurationLike,
+	optionalMinimumVersion doc.VersionLike,
+) {
+	if uti.IsDefined(v.getRule(type_)) {
+		panic("The policy already contains a rule for this type: " + type_.AsSource())
+	}
+	var tags []string
+	for _, notary := range notaries {
+		tags = append(tags, notary.AsSource())
+	}
+	var maximumAge = "none"
+	if uti.IsDefined(optionalMaximumAge) {
+		maximumAge = optionalMaximumAge.AsSource()
+	}
+	var minimumVersion = "none"
+	if uti.IsDefined(optionalMinimumVersion) {
+		minimumVersion = optionalMinimumVersion.AsSource()
+	}
+	var source = `[
+    $type: ` + type_.AsSource() + `
+    $notaries: [ ` + sts.Join(tags, " ") + ` ]
+    $maximumAge: ` + maximumAge + `
+    $minimumVersion: ` + minimumVersion + `
+]`
+	v.SetSubcomponent(
+		doc.ParseComponent(source),
+		doc.Symbol("$rules"),
+		0,
+	)
+}
+
+func (v *policy_) GetTypes() []doc.NameLike {
+	var types []doc.NameLike
+	var component = v.GetSubcomponent(doc.Symbol("$rules"))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var type_ = iterator.GetNext().GetSubcomponent(doc.Symbol("$type"))
+		types = append(types, doc.Name(doc.FormatComponent(type_)))
+	}
+	return types
+}
+
+func (v *policy_) GetNotaries(
+	type_ doc.NameLike,
+) []doc.TagLike {
+	var notaries []doc.TagLike
+	var rule = v.getRule(type_)
+	if uti.IsUndefined(rule) {
+		return notaries
+	}
+	var component = rule.GetSubcomponent(doc.Symbol("$notaries"))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var notary = iterator.GetNext()
+		notaries = append(notaries, doc.Tag(doc.FormatComponent(notary)))
+	}
+	return notaries
+}
+
+func (v *policy_) GetOptionalMaximumAge(
+	type_ doc.NameLike,
+) doc.DurationLike {
+	var maximumAge doc.DurationLike
+	var rule = v.getRule(type_)
+	if uti.IsDefined(rule) {
+		var component = rule.GetSubcomponent(doc.Symbol("$maximumAge"))
+		var source = doc.FormatComponent(component)
+		if source != "none" {
+			maximumAge = doc.Duration(source)
+		}
+	}
+	return maximumAge
+}
+
+func (v *policy_) GetOptionalMinimumVersion(
+	type_ doc.NameLike,
+) doc.VersionLike {
+	var minimumVersion doc.VersionLike
+	var rule = v.getRule(type_)
+	if uti.IsDefined(rule) {
+		var component = rule.GetSubcomponent(doc.Symbol("$minimumVersion"))
+		var source = doc.FormatComponent(component)
+		if source != "none" {
+			minimumVersion = doc.Version(source)
+		}
+	}
+	return minimumVersion
+}
+
+// Parameterized Methods
+
+func (v *policy_) GetType() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$type"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *policy_) GetTag() doc.TagLike {
+	var component = v.GetConstraint(doc.Symbol("$tag"))
+	return doc.Tag(doc.FormatComponent(component))
+}
+
+func (v *policy_) GetVersion() doc.VersionLike {
+	var component = v.GetConstraint(doc.Symbol("$version"))
+	return doc.Version(doc.FormatComponent(component))
+}
+
+func (v *policy_) GetPermissions() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$permissions"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *policy_) GetOptionalPrevious() doc.ResourceLike {
+	var previous doc.ResourceLike
+	var component = v.GetConstraint(doc.Symbol("$previous"))
+	if uti.IsDefined(component) {
+		var source = doc.FormatComponent(component)
+		if source != "none" {
+			previous = doc.Resource(source)
+		}
+	}
+	return previous
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *policyClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Policy/v3", violations)
+}
+
+func (v *policy_) getRule(
+	type_ doc.NameLike,
+) doc.Composite {
+	var component = v.GetSubcomponent(doc.Symbol("$rules"))
+	var iterator = component.GetLiteral().(doc.ItemsLike).GetComponents().GetIterator()
+	for iterator.HasNext() {
+		var rule = iterator.GetNext()
+		var name = rule.GetSubcomponent(doc.Symbol("$type"))
+		if doc.FormatComponent(name) == type_.AsSource() {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Instance Structure
+
+type policy_ struct {
+	// Declare the instance attributes.
+
+	// Declare the inherited aspects.
+	doc.Composite
+}
+
+// Class Structure
+
+type policyClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func policyClass() *policyClass_ {
+	return policyClassReference_
+}
+
+var policyClassReference_ = &policyClass_{
+	// Initialize the class constants.
+}
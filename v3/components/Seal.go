@@ -38,6 +38,7 @@ func (c *sealClass_) Seal(
 		panic("The \"signature\" attribute is required by this class.")
 	}
 
+	// Seals without a format attribute were signed using the formatted source.
 	var source = `[
     $algorithm: ` + algorithm.AsSource() + `
     $signature: ` + signature.AsSource() + `
@@ -45,10 +46,34 @@ func (c *sealClass_) Seal(
 	return c.SealFromSource(source)
 }
 
+func (c *sealClass_) SealWithFormat(
+	algorithm doc.QuoteLike,
+	signature doc.BinaryLike,
+	format doc.NameLike,
+) SealLike {
+	if uti.IsUndefined(algorithm) {
+		panic("The \"algorithm\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(signature) {
+		panic("The \"signature\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(format) {
+		panic("The \"format\" attribute is required by this class.")
+	}
+
+	var source = `[
+    $algorithm: ` + algorithm.AsSource() + `
+    $signature: ` + signature.AsSource() + `
+    $format: ` + format.AsSource() + `
+]($type: /bali/types/notary/Seal/v3)`
+	return c.SealFromSource(source)
+}
+
 func (c *sealClass_) SealFromSource(
 	source string,
 ) SealLike {
 	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
 	var instance = &seal_{
 		// Initialize the instance attributes.
 
@@ -62,6 +87,17 @@ func (c *sealClass_) SealFromSource(
 
 // Function Methods
 
+func (c *sealClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/Seal/v3")
+	validator.ValidateAttribute("$algorithm", QuoteKind, false)
+	validator.ValidateAttribute("$signature", BinaryKind, false)
+	validator.ValidateSize("$signature", "ED25519", 64)
+	return validator.GetViolations()
+}
+
 // INSTANCE INTERFACE
 
 // Principal Methods
@@ -90,10 +126,26 @@ func (v *seal_) GetSignature() doc.BinaryLike {
 	return doc.Binary(doc.FormatComponent(component))
 }
 
+func (v *seal_) GetOptionalFormat() doc.NameLike {
+	var format doc.NameLike
+	var component = v.GetSubcomponent(doc.Symbol("$format"))
+	if uti.IsDefined(component) {
+		format = doc.Name(doc.FormatComponent(component))
+	}
+	return format
+}
+
 // PROTECTED INTERFACE
 
 // Private Methods
 
+func (c *sealClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/Seal/v3", violations)
+}
+
 // Instance Structure
 
 type seal_ struct {
@@ -0,0 +1,213 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	stc "strconv"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func TreeHeadClass() TreeHeadClassLike {
+	return treeHeadClass()
+}
+
+// Constructor Methods
+
+func (c *treeHeadClass_) TreeHead(
+	algorithm doc.QuoteLike,
+	size uint,
+	root doc.BinaryLike,
+	tag doc.TagLike,
+	version doc.VersionLike,
+	optionalPrevious doc.ResourceLike,
+) TreeHeadLike {
+	if uti.IsUndefined(algorithm) {
+		panic("The \"algorithm\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(root) {
+		panic("The \"root\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(tag) {
+		panic("The \"tag\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(version) {
+		panic("The \"version\" attribute is required by this class.")
+	}
+
+	var timestamp = doc.Moment() // The current date and time.
+	var previous = "none"
+	if uti.IsDefined(optionalPrevious) {
+		previous = optionalPrevious.AsSource()
+	}
+	var source = `[
+    $algorithm: ` + algorithm.AsSource() + `
+    $size: ` + stc.Itoa(int(size)) + `
+    $root: ` + root.AsSource() + `
+    $timestamp: ` + timestamp.AsSource() + `
+](
+    $type: /bali/types/notary/TreeHead/v3
+    $tag: ` + tag.AsSource() + `
+    $version: ` + version.AsSource() + `
+    $permissions: /bali/permissions/Public/v3
+    $previous: ` + previous + `
+)`
+	return c.TreeHeadFromSource(source)
+}
+
+func (c *treeHeadClass_) TreeHeadFromSource(
+	source string,
+) TreeHeadLike {
+	var component = doc.ParseComponent(source)
+	c.checkViolations(component)
+	var instance = &treeHead_{
+		// Initialize the instance attributes.
+
+		// Initialize the inherited aspects.
+		Composite: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *treeHeadClass_) Violations(
+	component doc.Composite,
+) []string {
+	var validator = ValidatorClass().Validator(component)
+	validator.ValidateType("/bali/types/notary/TreeHead/v3")
+	validator.ValidateParameter("$tag", TagKind, false)
+	validator.ValidateParameter("$version", VersionKind, false)
+	validator.ValidateParameter("$permissions", NameKind, false)
+	validator.ValidateParameter("$previous", ResourceKind, true)
+	validator.ValidateAttribute("$algorithm", QuoteKind, false)
+	validator.ValidateAttribute("$size", NumberKind, false)
+	validator.ValidateAttribute("$root", BinaryKind, false)
+	validator.ValidateAttribute("$timestamp", MomentKind, false)
+	return validator.GetViolations()
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *treeHead_) GetClass() TreeHeadClassLike {
+	return treeHeadClass()
+}
+
+func (v *treeHead_) AsIntrinsic() doc.Composite {
+	return v.Composite
+}
+
+func (v *treeHead_) AsSource() string {
+	return doc.FormatComponent(v.Composite) + "\n"
+}
+
+// Attribute Methods
+
+func (v *treeHead_) GetAlgorithm() doc.QuoteLike {
+	var component = v.GetSubcomponent(doc.Symbol("$algorithm"))
+	return doc.Quote(doc.FormatComponent(component))
+}
+
+func (v *treeHead_) GetSize() uint {
+	var component = v.GetSubcomponent(doc.Symbol("$size"))
+	var size, _ = stc.Atoi(doc.FormatComponent(component))
+	return uint(size)
+}
+
+func (v *treeHead_) GetRoot() doc.BinaryLike {
+	var component = v.GetSubcomponent(doc.Symbol("$root"))
+	return doc.Binary(doc.FormatComponent(component))
+}
+
+func (v *treeHead_) GetTimestamp() doc.MomentLike {
+	var component = v.GetSubcomponent(doc.Symbol("$timestamp"))
+	return doc.Moment(doc.FormatComponent(component))
+}
+
+// Parameterized Methods
+
+func (v *treeHead_) GetType() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$type"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *treeHead_) GetTag() doc.TagLike {
+	var component = v.GetConstraint(doc.Symbol("$tag"))
+	return doc.Tag(doc.FormatComponent(component))
+}
+
+func (v *treeHead_) GetVersion() doc.VersionLike {
+	var component = v.GetConstraint(doc.Symbol("$version"))
+	return doc.Version(doc.FormatComponent(component))
+}
+
+func (v *treeHead_) GetPermissions() doc.NameLike {
+	var component = v.GetConstraint(doc.Symbol("$permissions"))
+	return doc.Name(doc.FormatComponent(component))
+}
+
+func (v *treeHead_) GetOptionalPrevious() doc.ResourceLike {
+	var previous doc.ResourceLike
+	var component = v.GetConstraint(doc.Symbol("$previous"))
+	if uti.IsDefined(component) {
+		var source = doc.FormatComponent(component)
+		if source != "none" {
+			previous = doc.Resource(source)
+		}
+	}
+	return previous
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (c *treeHeadClass_) checkViolations(
+	component doc.Composite,
+) {
+	var violations = c.Violations(component)
+	ValidatorClass().CheckViolations("/bali/types/notary/TreeHead/v3", violations)
+}
+
+// Instance Structure
+
+type treeHead_ struct {
+	// Declare the instance attributes.
+
+	// Declare the inherited aspects.
+	doc.Composite
+}
+
+// Class Structure
+
+type treeHeadClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func treeHeadClass() *treeHeadClass_ {
+	return treeHeadClassReference_
+}
+
+var treeHeadClassReference_ = &treeHeadClass_{
+	// Initialize the class constants.
+}
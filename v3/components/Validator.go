@@ -0,0 +1,279 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package components
+
+import (
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	sts "strings"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func ValidatorClass() ValidatorClassLike {
+	return validatorClass()
+}
+
+// Constructor Methods
+
+func (c *validatorClass_) Validator(
+	component doc.Composite,
+) ValidatorLike {
+	var instance = &validator_{
+		// Initialize the instance attributes.
+		component_: component,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+func (c *validatorClass_) CheckViolations(
+	type_ string,
+	violations []string,
+) {
+	if len(violations) > 0 {
+		var message = fmt.Sprintf(
+			"The source is not a valid %s document:\n    %s",
+			type_,
+			sts.Join(violations, "\n    "),
+		)
+		panic(message)
+	}
+}
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *validator_) GetClass() ValidatorClassLike {
+	return validatorClass()
+}
+
+// Attribute Methods
+
+func (v *validator_) GetViolations() []string {
+	return v.violations_
+}
+
+// Validating Methods
+
+func (v *validator_) ValidateType(
+	type_ string,
+) {
+	var component = v.getParameter("$type")
+	if uti.IsUndefined(component) {
+		v.addViolation("The $type parameter is missing.")
+		return
+	}
+	var source = doc.FormatComponent(component)
+	if source != type_ {
+		var message = fmt.Sprintf(
+			"The $type parameter must be %s, not %s.",
+			type_,
+			source,
+		)
+		v.addViolation(message)
+	}
+}
+
+func (v *validator_) ValidateAttribute(
+	key string,
+	kind Kind,
+	optional bool,
+) {
+	var component = v.getAttribute(key)
+	v.validateKind("attribute", key, component, kind, optional)
+}
+
+func (v *validator_) ValidateParameter(
+	key string,
+	kind Kind,
+	optional bool,
+) {
+	var component = v.getParameter(key)
+	v.validateKind("parameter", key, component, kind, optional)
+}
+
+func (v *validator_) ValidateSize(
+	key string,
+	algorithm string,
+	size uint,
+) {
+	// The required size depends on the algorithm.
+	var component = v.getAttribute("$algorithm")
+	if uti.IsUndefined(component) {
+		return
+	}
+	var quote, ok = component.GetLiteral().(doc.QuoteLike)
+	if !ok || string(quote.AsIntrinsic()) != algorithm {
+		return
+	}
+
+	// Check the size of the binary value.
+	component = v.getAttribute(key)
+	if uti.IsUndefined(component) {
+		return
+	}
+	binary, ok := component.GetLiteral().(doc.BinaryLike)
+	if ok && binary.GetSize() != size {
+		var message = fmt.Sprintf(
+			"The %s attribute must contain %d bytes for the %s algorithm, not %d.",
+			key,
+			size,
+			algorithm,
+			binary.GetSize(),
+		)
+		v.addViolation(message)
+	}
+}
+
+func (v *validator_) ValidateComponent(
+	key string,
+	violations []string,
+) {
+	for _, violation := range violations {
+		v.addViolation(key + ": " + violation)
+	}
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *validator_) addViolation(
+	violation string,
+) {
+	v.violations_ = append(v.violations_, violation)
+}
+
+func (v *validator_) getAttribute(
+	key string,
+) doc.Composite {
+	if uti.IsUndefined(v.component_) {
+		return nil
+	}
+	var _, ok = v.component_.GetLiteral().(doc.AttributesLike)
+	if !ok {
+		return nil
+	}
+	return v.component_.GetSubcomponent(doc.Symbol(key))
+}
+
+func (v *validator_) getParameter(
+	key string,
+) doc.Composite {
+	if uti.IsUndefined(v.component_) {
+		return nil
+	}
+	return v.component_.GetConstraint(doc.Symbol(key))
+}
+
+func (v *validator_) validateKind(
+	role string,
+	key string,
+	component doc.Composite,
+	kind Kind,
+	optional bool,
+) {
+	if uti.IsUndefined(component) {
+		v.addViolation(fmt.Sprintf("The %s %s is missing.", key, role))
+		return
+	}
+	var literal = component.GetLiteral()
+	if _, ok := literal.(doc.PatternLike); ok && optional {
+		if doc.FormatComponent(component) == "none" {
+			return
+		}
+	}
+	var ok bool
+	switch kind {
+	case AnyKind:
+		ok = true
+	case AttributesKind:
+		_, ok = literal.(doc.AttributesLike)
+	case BinaryKind:
+		_, ok = literal.(doc.BinaryLike)
+	case DurationKind:
+		_, ok = literal.(doc.DurationLike)
+	case ItemsKind:
+		_, ok = literal.(doc.ItemsLike)
+	case MomentKind:
+		_, ok = literal.(doc.MomentLike)
+	case NameKind:
+		_, ok = literal.(doc.NameLike)
+	case NumberKind:
+		_, ok = literal.(doc.NumberLike)
+	case QuoteKind:
+		_, ok = literal.(doc.QuoteLike)
+	case ResourceKind:
+		_, ok = literal.(doc.ResourceLike)
+	case TagKind:
+		_, ok = literal.(doc.TagLike)
+	case VersionKind:
+		_, ok = literal.(doc.VersionLike)
+	}
+	if !ok {
+		var message = fmt.Sprintf(
+			"The %s %s must be a %s value.",
+			key,
+			role,
+			validatorClass().kinds_[kind],
+		)
+		v.addViolation(message)
+	}
+}
+
+// Instance Structure
+
+type validator_ struct {
+	// Declare the instance attributes.
+	component_  doc.Composite
+	violations_ []string
+}
+
+// Class Structure
+
+type validatorClass_ struct {
+	// Declare the class constants.
+	kinds_ map[Kind]string
+}
+
+// Class Reference
+
+func validatorClass() *validatorClass_ {
+	return validatorClassReference_
+}
+
+var validatorClassReference_ = &validatorClass_{
+	// Initialize the class constants.
+	kinds_: map[Kind]string{
+		AnyKind:        "defined",
+		AttributesKind: "attributes",
+		BinaryKind:     "binary",
+		DurationKind:   "duration",
+		ItemsKind:      "items",
+		MomentKind:     "moment",
+		NameKind:       "name",
+		NumberKind:     "number",
+		QuoteKind:      "quote",
+		ResourceKind:   "resource",
+		TagKind:        "tag",
+		VersionKind:    "version",
+	},
+}
@@ -34,10 +34,87 @@ import (
 
 // TYPE DECLARATIONS
 
+/*
+Kind is a constrained type representing the kind of value that an attribute or
+parameter of a notary component must have.
+*/
+type Kind uint8
+
+const (
+	AnyKind Kind = iota
+	AttributesKind
+	BinaryKind
+	DurationKind
+	ItemsKind
+	MomentKind
+	NameKind
+	NumberKind
+	QuoteKind
+	ResourceKind
+	TagKind
+	VersionKind
+)
+
 // FUNCTIONAL DECLARATIONS
 
 // CLASS DECLARATIONS
 
+/*
+BundleClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+bundle-like class.  An evidence bundle contains a notarized document along with
+every version of each certificate that it depends on, the status of those
+certificates and the timestamps for the document, so that the document can be
+verified offline long after it was notarized.
+*/
+type BundleClassLike interface {
+	// Constructor Methods
+	Bundle(
+		document DocumentLike,
+		tag doc.TagLike,
+		version doc.VersionLike,
+		optionalPrevious doc.ResourceLike,
+	) BundleLike
+	BundleFromSource(
+		source string,
+	) BundleLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+CanonicalClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete canonical-like class.  The canonical form of a document is a versioned
+byte encoding that does not depend on how the document happens to be formatted,
+so it is used when signing and digesting documents.  Each primitive value is
+encoded as its intrinsic value, and the name of the format is encoded first so
+that the format is covered by each signature.  The legacy format, which encoded
+most primitive values as their source, is only used to verify existing seals
+and citations.  The JWS and COSE formats are only used to seal documents
+containing a payload, and their bytes are the JWS signing input and COSE_Sign1
+signature structure for that payload.
+*/
+type CanonicalClassLike interface {
+	// Constant Methods
+	Format() doc.NameLike
+	LegacyFormat() doc.NameLike
+	JWSFormat() doc.NameLike
+	COSEFormat() doc.NameLike
+
+	// Function Methods
+	CanonicalBytes(
+		component doc.Composite,
+	) []byte
+	FormatBytes(
+		component doc.Composite,
+		optionalFormat doc.NameLike,
+	) []byte
+}
+
 /*
 CitationClassLike is a class interface that declares the complete set of
 class constructors, constants and functions that must be supported by each
@@ -57,6 +134,40 @@ type CitationClassLike interface {
 	CitationFromSource(
 		source string,
 	) CitationLike
+	CitationFromJSON(
+		source string,
+	) CitationLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+ConsistencyProofClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete consistency-proof-like class.
+
+A consistency proof shows that the first tree of a transparency log is a prefix
+of the second tree, so no entries were removed or changed between them.
+*/
+type ConsistencyProofClassLike interface {
+	// Constructor Methods
+	ConsistencyProof(
+		algorithm doc.QuoteLike,
+		firstSize uint,
+		secondSize uint,
+		path []doc.BinaryLike,
+	) ConsistencyProofLike
+	ConsistencyProofFromSource(
+		source string,
+	) ConsistencyProofLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
 }
 
 /*
@@ -77,6 +188,11 @@ type ContentClassLike interface {
 	ContentFromSource(
 		source string,
 	) ContentLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
 }
 
 /*
@@ -92,6 +208,42 @@ type DocumentClassLike interface {
 	DocumentFromSource(
 		source string,
 	) DocumentLike
+	DocumentFromJSON(
+		source string,
+	) DocumentLike
+	DocumentFromJWS(
+		source string,
+	) DocumentLike
+	DocumentFromCOSE(
+		bytes []byte,
+	) DocumentLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+EnvelopeClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete envelope-like class.
+*/
+type EnvelopeClassLike interface {
+	// Constructor Methods
+	Envelope(
+		algorithm doc.QuoteLike,
+		ephemeralKey doc.BinaryLike,
+		ciphertext doc.BinaryLike,
+	) EnvelopeLike
+	EnvelopeFromSource(
+		source string,
+	) EnvelopeLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
 }
 
 /*
@@ -112,6 +264,60 @@ type IdentityClassLike interface {
 	IdentityFromSource(
 		source string,
 	) IdentityLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+JSONClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+json-like class.  The JSON form of a component is a lossless mapping of its Bali
+form.  Lists map to arrays, catalogs map to objects with their keys in order,
+booleans map to booleans, binary values map to single quoted base64 strings and
+all other primitive values map to strings containing their Bali source.  Each
+such string must contain exactly one primitive value when it is parsed.  A
+component with parameters maps to an object containing only its "@value" and
+"@parameters".  Since seals are defined on the canonical form of a document, a
+document that is converted to JSON and back still matches its seal.
+*/
+type JSONClassLike interface {
+	// Function Methods
+	FormatJSON(
+		component doc.Composite,
+	) string
+	ParseJSON(
+		source string,
+	) doc.Composite
+}
+
+/*
+InclusionProofClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete inclusion-proof-like class.
+
+An inclusion proof shows that the citation at an index of a transparency log is
+included in the tree of the specified size, whose signed tree head is cited.
+*/
+type InclusionProofClassLike interface {
+	// Constructor Methods
+	InclusionProof(
+		algorithm doc.QuoteLike,
+		index uint,
+		size uint,
+		path []doc.BinaryLike,
+		treeHead doc.ResourceLike,
+	) InclusionProofLike
+	InclusionProofFromSource(
+		source string,
+	) InclusionProofLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
 }
 
 /*
@@ -128,6 +334,106 @@ type NotaryClassLike interface {
 	NotaryFromSource(
 		source string,
 	) NotaryLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+PayloadClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+payload-like class.
+
+A payload is the protected header and payload of a JWS or COSE_Sign1 signature.
+A document containing a payload is sealed using the JWS or COSE format so that
+its seal is the signature from the original JWS or COSE_Sign1 structure.  The
+protected header contains the key identifier and the issue time of the
+signature, from which the tag, version, permissions and notary of the document
+are derived, since nothing else in the document is covered by the signature.
+*/
+type PayloadClassLike interface {
+	// Constructor Methods
+	Payload(
+		protected doc.BinaryLike,
+		payload doc.BinaryLike,
+		tag doc.TagLike,
+		version doc.VersionLike,
+		optionalPrevious doc.ResourceLike,
+	) PayloadLike
+	PayloadFromSource(
+		source string,
+	) PayloadLike
+
+	// Function Methods
+	ProtectedHeader(
+		format doc.NameLike,
+		keyID string,
+		timestamp doc.MomentLike,
+	) doc.BinaryLike
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+PermissionsClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete permissions-like class.
+
+A permissions document defines which certificates may $read, $write or
+$notarize the documents whose content names it in its $permissions parameter.
+The public permissions allow anyone to read a document and the private
+permissions allow only its owner to access it.
+*/
+type PermissionsClassLike interface {
+	// Constructor Methods
+	Permissions(
+		name doc.NameLike,
+		tag doc.TagLike,
+		version doc.VersionLike,
+		optionalPrevious doc.ResourceLike,
+	) PermissionsLike
+	PermissionsFromSource(
+		source string,
+	) PermissionsLike
+
+	// Constant Methods
+	Public() doc.NameLike
+	Private() doc.NameLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+PolicyClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+policy-like class.
+
+A policy declares the rules that a relying party uses to decide which seals it
+accepts.  Each rule applies to documents of a single type and may restrict the
+certificates that notarized them, the maximum age of their seals and the
+minimum version of those certificates.
+*/
+type PolicyClassLike interface {
+	// Constructor Methods
+	Policy(
+		tag doc.TagLike,
+		version doc.VersionLike,
+		optionalPrevious doc.ResourceLike,
+	) PolicyLike
+	PolicyFromSource(
+		source string,
+	) PolicyLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
 }
 
 /*
@@ -141,13 +447,106 @@ type SealClassLike interface {
 		algorithm doc.QuoteLike,
 		signature doc.BinaryLike,
 	) SealLike
+	SealWithFormat(
+		algorithm doc.QuoteLike,
+		signature doc.BinaryLike,
+		format doc.NameLike,
+	) SealLike
 	SealFromSource(
 		source string,
 	) SealLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+TreeHeadClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete tree-head-like class.
+
+A tree head records the size and Merkle root of a transparency log.  Each
+version of a tree head is notarized by the log, and version N of a tree head
+corresponds to the tree containing the first N citations.
+*/
+type TreeHeadClassLike interface {
+	// Constructor Methods
+	TreeHead(
+		algorithm doc.QuoteLike,
+		size uint,
+		root doc.BinaryLike,
+		tag doc.TagLike,
+		version doc.VersionLike,
+		optionalPrevious doc.ResourceLike,
+	) TreeHeadLike
+	TreeHeadFromSource(
+		source string,
+	) TreeHeadLike
+
+	// Function Methods
+	Violations(
+		component doc.Composite,
+	) []string
+}
+
+/*
+ValidatorClassLike is a class interface that declares the complete set of
+class constructors, constants and functions that must be supported by each
+concrete validator-like class.
+*/
+type ValidatorClassLike interface {
+	// Constructor Methods
+	Validator(
+		component doc.Composite,
+	) ValidatorLike
+
+	// Function Methods
+	CheckViolations(
+		type_ string,
+		violations []string,
+	)
 }
 
 // INSTANCE DECLARATIONS
 
+/*
+BundleLike is an instance interface that declares the complete set of principal,
+attribute and aspect methods that must be supported by each instance of a
+concrete bundle-like class.  The certificates are ordered from the first version
+of each certificate.  The status contains a citation to the latest version of
+each certificate when the bundle was assembled, since each earlier version was
+superseded when its next version was notarized.  A certificate cannot otherwise
+be revoked so the bundle carries no revocation information.  The timestamps are
+notarized transparency log tree heads that include the document rather than
+RFC 3161 timestamp tokens, so they are only as trustworthy as the certificate
+that sealed them.
+*/
+type BundleLike interface {
+	// Principal Methods
+	GetClass() BundleClassLike
+	AsIntrinsic() doc.Composite
+
+	// Attribute Methods
+	GetDocument() DocumentLike
+	AddCertificate(
+		certificate DocumentLike,
+	)
+	GetCertificates() []DocumentLike
+	AddStatus(
+		citation CitationLike,
+	)
+	GetStatus() []CitationLike
+	AddTimestamp(
+		treeHead DocumentLike,
+	)
+	GetTimestamps() []DocumentLike
+
+	// Aspect Interfaces
+	Parameterized
+}
+
 /*
 CitationLike is an instance interface that declares the complete set of
 principal, attribute and aspect methods that must be supported by each instance
@@ -158,6 +557,7 @@ type CitationLike interface {
 	GetClass() CitationClassLike
 	AsIntrinsic() doc.Composite
 	AsSource() string
+	AsJSON() string
 	AsResource() doc.ResourceLike
 
 	// Attribute Methods
@@ -167,6 +567,24 @@ type CitationLike interface {
 	GetDigest() doc.BinaryLike
 }
 
+/*
+ConsistencyProofLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete consistency-proof-like class.
+*/
+type ConsistencyProofLike interface {
+	// Principal Methods
+	GetClass() ConsistencyProofClassLike
+	AsIntrinsic() doc.Composite
+	AsSource() string
+
+	// Attribute Methods
+	GetAlgorithm() doc.QuoteLike
+	GetFirstSize() uint
+	GetSecondSize() uint
+	GetPath() []doc.BinaryLike
+}
+
 /*
 ContentLike is an instance interface that declares the complete set of
 principal, attribute and aspect methods that must be supported by each instance
@@ -191,6 +609,10 @@ type DocumentLike interface {
 	GetClass() DocumentClassLike
 	AsIntrinsic() doc.Composite
 	AsSource() string
+	AsJSON() string
+	AsJWS() string
+	AsCOSE() []byte
+	AsCanonical() []byte
 
 	// Attribute Methods
 	GetContent() Parameterized
@@ -204,15 +626,47 @@ type DocumentLike interface {
 		seal SealLike,
 	)
 	RemoveNotarySeal() SealLike
+	SetNotaryProof(
+		proof InclusionProofLike,
+	)
+	RemoveNotaryProof() InclusionProofLike
 
 	// Aspect Interfaces
 	doc.Composite
 }
 
+/*
+EnvelopeLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete envelope-like class.  An envelope contains an encrypted notarized
+document along with the content key wrapped for each of its recipients.
+*/
+type EnvelopeLike interface {
+	// Principal Methods
+	GetClass() EnvelopeClassLike
+	AsIntrinsic() doc.Composite
+	AsSource() string
+
+	// Attribute Methods
+	GetAlgorithm() doc.QuoteLike
+	GetEphemeralKey() doc.BinaryLike
+	GetCiphertext() doc.BinaryLike
+	AddRecipient(
+		recipient doc.ResourceLike,
+		wrappedKey doc.BinaryLike,
+	)
+	GetRecipients() []doc.ResourceLike
+	GetWrappedKey(
+		recipient doc.ResourceLike,
+	) doc.BinaryLike
+}
+
 /*
 IdentityLike is an instance interface that declares the complete set of
 principal, attribute and aspect methods that must be supported by each instance
-of a concrete identity-like class.
+of a concrete identity-like class.  In addition to its signing key an identity
+may carry other named public keys, each with its own algorithm (for example an
+"$agreementKey" used to encrypt documents for the identity).
 */
 type IdentityLike interface {
 	// Principal Methods
@@ -223,11 +677,41 @@ type IdentityLike interface {
 	GetAlgorithm() doc.QuoteLike
 	GetKey() doc.BinaryLike
 	GetAttributes() doc.Composite
+	AddPublicKey(
+		name string,
+		algorithm doc.QuoteLike,
+		key doc.BinaryLike,
+	)
+	GetOptionalKeyAlgorithm(
+		name string,
+	) doc.QuoteLike
+	GetOptionalPublicKey(
+		name string,
+	) doc.BinaryLike
 
 	// Aspect Interfaces
 	Parameterized
 }
 
+/*
+InclusionProofLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete inclusion-proof-like class.
+*/
+type InclusionProofLike interface {
+	// Principal Methods
+	GetClass() InclusionProofClassLike
+	AsIntrinsic() doc.Composite
+	AsSource() string
+
+	// Attribute Methods
+	GetAlgorithm() doc.QuoteLike
+	GetIndex() uint
+	GetSize() uint
+	GetPath() []doc.BinaryLike
+	GetTreeHead() doc.ResourceLike
+}
+
 /*
 NotaryLike is an instance interface that declares the complete set of
 principal, attribute and aspect methods that must be supported by each instance
@@ -244,6 +728,90 @@ type NotaryLike interface {
 	GetTimestamp() doc.MomentLike
 	GetOptionalCitation() CitationLike
 	GetOptionalSeal() SealLike
+	GetOptionalProof() InclusionProofLike
+}
+
+/*
+PayloadLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete payload-like class.
+*/
+type PayloadLike interface {
+	// Principal Methods
+	GetClass() PayloadClassLike
+	AsIntrinsic() doc.Composite
+
+	// Attribute Methods
+	GetProtected() doc.BinaryLike
+	GetPayload() doc.BinaryLike
+	GetAlgorithm() string
+	GetKeyID() string
+	GetTimestamp() doc.MomentLike
+
+	// Aspect Interfaces
+	Parameterized
+}
+
+/*
+PermissionsLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete permissions-like class.  The grants for each action are the tags
+of the certificates that may perform it.
+*/
+type PermissionsLike interface {
+	// Principal Methods
+	GetClass() PermissionsClassLike
+	AsIntrinsic() doc.Composite
+
+	// Attribute Methods
+	GetName() doc.NameLike
+	GrantAccess(
+		action string,
+		certificate doc.TagLike,
+	)
+	GetGrants(
+		action string,
+	) []doc.TagLike
+	IsGranted(
+		action string,
+		certificate doc.TagLike,
+	) bool
+
+	// Aspect Interfaces
+	Parameterized
+}
+
+/*
+PolicyLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete policy-like class.  An empty list of notaries in a rule accepts
+any certificate.
+*/
+type PolicyLike interface {
+	// Principal Methods
+	GetClass() PolicyClassLike
+	AsIntrinsic() doc.Composite
+
+	// Attribute Methods
+	AddRule(
+		type_ doc.NameLike,
+		notaries []doc.TagLike,
+		optionalMaximumAge doc.DurationLike,
+		optionalMinimumVersion doc.VersionLike,
+	)
+	GetTypes() []doc.NameLike
+	GetNotaries(
+		type_ doc.NameLike,
+	) []doc.TagLike
+	GetOptionalMaximumAge(
+		type_ doc.NameLike,
+	) doc.DurationLike
+	GetOptionalMinimumVersion(
+		type_ doc.NameLike,
+	) doc.VersionLike
+
+	// Aspect Interfaces
+	Parameterized
 }
 
 /*
@@ -260,6 +828,44 @@ type SealLike interface {
 	// Attribute Methods
 	GetAlgorithm() doc.QuoteLike
 	GetSignature() doc.BinaryLike
+	GetOptionalFormat() doc.NameLike
+}
+
+/*
+TreeHeadLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete tree-head-like class.
+*/
+type TreeHeadLike interface {
+	// Principal Methods
+	GetClass() TreeHeadClassLike
+	AsIntrinsic() doc.Composite
+
+	// Attribute Methods
+	GetAlgorithm() doc.QuoteLike
+	GetSize() uint
+	GetRoot() doc.BinaryLike
+	GetTimestamp() doc.MomentLike
+
+	// Aspect Interfaces
+	Parameterized
+}
+
+/*
+ValidatorLike is an instance interface that declares the complete set of
+principal, attribute and aspect methods that must be supported by each instance
+of a concrete validator-like class.  A validator accumulates the violations of
+a component against the structure required by its notary type.
+*/
+type ValidatorLike interface {
+	// Principal Methods
+	GetClass() ValidatorClassLike
+
+	// Attribute Methods
+	GetViolations() []string
+
+	// Aspect Interfaces
+	Validating
 }
 
 // ASPECT DECLARATIONS
@@ -277,3 +883,32 @@ type Parameterized interface {
 	GetPermissions() doc.NameLike
 	GetOptionalPrevious() doc.ResourceLike
 }
+
+/*
+Validating declares the set of method signatures that must be supported by all
+validators of notary components.
+*/
+type Validating interface {
+	ValidateType(
+		type_ string,
+	)
+	ValidateAttribute(
+		key string,
+		kind Kind,
+		optional bool,
+	)
+	ValidateParameter(
+		key string,
+		kind Kind,
+		optional bool,
+	)
+	ValidateSize(
+		key string,
+		algorithm string,
+		size uint,
+	)
+	ValidateComponent(
+		key string,
+		violations []string,
+	)
+}
@@ -3,6 +3,7 @@ module github.com/bali-nebula/go-digital-notary/v3
 go 1.25
 
 require (
+	filippo.io/edwards25519 v1.1.0
 	github.com/bali-nebula/go-bali-documents/v3 v3.66.0
 	github.com/craterdog/go-essential-utilities/v8 v8.4.0
 	github.com/stretchr/testify v1.11.1
@@ -0,0 +1,253 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package module_test
+
+import (
+	ecc "crypto/ecdsa"
+	ell "crypto/elliptic"
+	ran "crypto/rand"
+	dig "crypto/sha512"
+	fmt "fmt"
+	not "github.com/bali-nebula/go-digital-notary/v3"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func HsmP384TestClass() *hsmP384Class_ {
+	return hsmP384Class()
+}
+
+// Constructor Methods
+
+func (c *hsmP384Class_) HsmP384() not.Hardened {
+	var instance = &hsmP384_{
+		// Initialize the instance attributes.
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Hardened Methods
+
+func (v *hsmP384_) GetSignatureAlgorithm() string {
+	return hsmP384Class().algorithm_
+}
+
+func (v *hsmP384_) GetPublicKey() []byte {
+	return v.publicKey(v.privateKey_)
+}
+
+func (v *hsmP384_) GenerateKeys() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to generate new keys",
+	)
+
+	if uti.IsDefined(v.privateKey_) {
+		panic("The HSM already has keys.")
+	}
+	v.privateKey_ = v.generateKey()
+	return v.GetPublicKey()
+}
+
+func (v *hsmP384_) SignBytes(
+	bytes []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to sign bytes",
+	)
+
+	if uti.IsUndefined(v.privateKey_) || uti.IsDefined(v.previousKey_) {
+		panic("The HSM cannot sign bytes in its current state.")
+	}
+	return v.sign(v.privateKey_, bytes)
+}
+
+func (v *hsmP384_) IsValid(
+	key []byte,
+	bytes []byte,
+	signature []byte,
+) bool {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to verify bytes signature",
+	)
+
+	var publicKey, err = ecc.ParseUncompressedPublicKey(ell.P384(), key)
+	if err != nil {
+		return false
+	}
+	var digest = dig.Sum384(bytes)
+	return ecc.VerifyASN1(publicKey, digest[:], signature)
+}
+
+func (v *hsmP384_) RotateKeys() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to rotate keys",
+	)
+
+	if uti.IsUndefined(v.privateKey_) || uti.IsDefined(v.previousKey_) {
+		panic("The HSM cannot rotate its keys in its current state.")
+	}
+	v.previousKey_ = v.privateKey_
+	v.privateKey_ = v.generateKey()
+	v.record_ = nil
+	v.signature_ = nil
+	return v.GetPublicKey()
+}
+
+func (v *hsmP384_) GetPreviousKey() []byte {
+	return v.publicKey(v.previousKey_)
+}
+
+func (v *hsmP384_) RecordRotation(
+	record []byte,
+) {
+	if uti.IsUndefined(v.previousKey_) {
+		panic("A key rotation is not pending.")
+	}
+	v.record_ = record
+}
+
+func (v *hsmP384_) GetRotationRecord() []byte {
+	return v.record_
+}
+
+func (v *hsmP384_) SignWithPreviousKey(
+	bytes []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to sign bytes with the previous key",
+	)
+
+	// Use the previous key one last time and then erase it.
+	if uti.IsUndefined(v.previousKey_) {
+		panic("A key rotation is not pending.")
+	}
+	var signature = v.sign(v.previousKey_, bytes)
+	v.previousKey_ = nil
+	v.signature_ = signature
+	return signature
+}
+
+func (v *hsmP384_) GetRotationSignature() []byte {
+	return v.signature_
+}
+
+func (v *hsmP384_) AbortRotation() []byte {
+	// Discard the new key pair and reinstate the previous one.
+	if uti.IsUndefined(v.previousKey_) {
+		panic("A key rotation is not pending.")
+	}
+	v.privateKey_ = v.previousKey_
+	v.previousKey_ = nil
+	v.record_ = nil
+	v.signature_ = nil
+	return v.GetPublicKey()
+}
+
+func (v *hsmP384_) EraseKeys() {
+	v.privateKey_ = nil
+	v.previousKey_ = nil
+	v.record_ = nil
+	v.signature_ = nil
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *hsmP384_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"HsmP384: %s:\n        %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *hsmP384_) generateKey() *ecc.PrivateKey {
+	var privateKey, err = ecc.GenerateKey(ell.P384(), ran.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return privateKey
+}
+
+func (v *hsmP384_) publicKey(
+	privateKey *ecc.PrivateKey,
+) []byte {
+	if uti.IsUndefined(privateKey) {
+		return nil
+	}
+	var bytes, err = privateKey.PublicKey.Bytes()
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func (v *hsmP384_) sign(
+	privateKey *ecc.PrivateKey,
+	bytes []byte,
+) []byte {
+	var digest = dig.Sum384(bytes)
+	var signature, err = ecc.SignASN1(ran.Reader, privateKey, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	return signature
+}
+
+// Instance Structure
+
+type hsmP384_ struct {
+	// Declare the instance attributes.
+	privateKey_  *ecc.PrivateKey
+	previousKey_ *ecc.PrivateKey
+	record_      []byte
+	signature_   []byte
+}
+
+// Class Structure
+
+type hsmP384Class_ struct {
+	// Declare the class constants.
+	algorithm_ string
+}
+
+// Class Reference
+
+func hsmP384Class() *hsmP384Class_ {
+	return hsmP384ClassReference_
+}
+
+var hsmP384ClassReference_ = &hsmP384Class_{
+	// Initialize the class constants.
+	algorithm_: "ECDSA-P384",
+}
@@ -13,7 +13,9 @@
 package module_test
 
 import (
+	ecd "crypto/ecdh"
 	sig "crypto/ed25519"
+	dig "crypto/sha512"
 	fmt "fmt"
 	doc "github.com/bali-nebula/go-bali-documents/v3"
 	not "github.com/bali-nebula/go-digital-notary/v3"
@@ -37,7 +39,9 @@ func (c *hsmEd25519Class_) HsmEd25519(
 	if uti.IsUndefined(tag) {
 		panic("The \"tag\" attribute is required by this class.")
 	}
-	var filename = testDirectory + "hsmEd25519/Configuration.bali"
+	var directory = testDirectory + "hsmEd25519/"
+	uti.MakeDirectory(directory)
+	var filename = directory + device + ".bali"
 	var controller = uti.Controller(c.events_, c.transitions_, c.keyless_)
 	var instance = &hsmEd25519_{
 		// Initialize the instance attributes.
@@ -101,13 +105,7 @@ func (v *hsmEd25519_) SignBytes(
 	)
 
 	v.controller_.ProcessEvent(hsmEd25519Class().signBytes_)
-	var privateKey = v.privateKey_
-	if v.previousKey_ != nil {
-		// Use the old key one more time to sign the new one.
-		privateKey = v.previousKey_
-		v.previousKey_ = nil
-	}
-	var signature = sig.Sign(privateKey, bytes)
+	var signature = sig.Sign(v.privateKey_, bytes)
 	v.writeConfiguration()
 	return signature
 }
@@ -138,6 +136,74 @@ func (v *hsmEd25519_) RotateKeys() []byte {
 	if err != nil {
 		panic(err)
 	}
+	v.record_ = nil
+	v.signature_ = nil
+	v.writeConfiguration()
+	return v.publicKey_
+}
+
+func (v *hsmEd25519_) GetPreviousKey() []byte {
+	var previousKey []byte
+	if uti.IsDefined(v.previousKey_) {
+		var privateKey = sig.PrivateKey(v.previousKey_)
+		previousKey = privateKey.Public().(sig.PublicKey)
+	}
+	return previousKey
+}
+
+func (v *hsmEd25519_) RecordRotation(
+	record []byte,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to record a key rotation",
+	)
+
+	if v.controller_.GetState() != hsmEd25519Class().twoKeys_ {
+		panic("A key rotation is not pending.")
+	}
+	v.record_ = record
+	v.writeConfiguration()
+}
+
+func (v *hsmEd25519_) GetRotationRecord() []byte {
+	return v.record_
+}
+
+func (v *hsmEd25519_) SignWithPreviousKey(
+	bytes []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to sign bytes with the previous key",
+	)
+
+	// Use the previous key one last time and then erase it.
+	v.controller_.ProcessEvent(hsmEd25519Class().signWithPreviousKey_)
+	var signature = sig.Sign(v.previousKey_, bytes)
+	v.previousKey_ = nil
+	v.signature_ = signature
+	v.writeConfiguration()
+	return signature
+}
+
+func (v *hsmEd25519_) GetRotationSignature() []byte {
+	return v.signature_
+}
+
+func (v *hsmEd25519_) AbortRotation() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to abort a key rotation",
+	)
+
+	// Discard the new key pair and reinstate the previous one.
+	v.controller_.ProcessEvent(hsmEd25519Class().abortRotation_)
+	v.privateKey_ = v.previousKey_
+	v.publicKey_ = v.GetPreviousKey()
+	v.previousKey_ = nil
+	v.record_ = nil
+	v.signature_ = nil
 	v.writeConfiguration()
 	return v.publicKey_
 }
@@ -151,10 +217,90 @@ func (v *hsmEd25519_) EraseKeys() {
 	v.createConfiguration(v.tag_)
 }
 
+// Exportable Methods
+
+func (v *hsmEd25519_) ExportKey() []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to export the private key",
+	)
+
+	v.controller_.ProcessEvent(hsmEd25519Class().exportKey_)
+	var privateKey = sig.PrivateKey(v.privateKey_)
+	return privateKey.Seed()
+}
+
+func (v *hsmEd25519_) ImportKey(
+	privateKey []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to import a private key",
+	)
+
+	if len(privateKey) != sig.SeedSize {
+		panic("The private key has an invalid length.")
+	}
+	v.controller_.ProcessEvent(hsmEd25519Class().importKey_)
+	var key = sig.NewKeyFromSeed(privateKey)
+	v.privateKey_ = key
+	v.publicKey_ = key.Public().(sig.PublicKey)
+	v.writeConfiguration()
+	return v.publicKey_
+}
+
+// Agreeing Methods
+
+func (v *hsmEd25519_) GetAgreementAlgorithm() string {
+	return "X25519"
+}
+
+func (v *hsmEd25519_) GetAgreementKey() []byte {
+	var agreementKey []byte
+	if uti.IsDefined(v.privateKey_) {
+		agreementKey = v.agreementKey().PublicKey().Bytes()
+	}
+	return agreementKey
+}
+
+func (v *hsmEd25519_) AgreeSecret(
+	publicKey []byte,
+) []byte {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to agree on a shared secret",
+	)
+
+	if uti.IsUndefined(v.privateKey_) {
+		panic("The HSM has no keys.")
+	}
+	var peerKey, err = ecd.X25519().NewPublicKey(publicKey)
+	if err != nil {
+		panic(err)
+	}
+	secret, err := v.agreementKey().ECDH(peerKey)
+	if err != nil {
+		panic(err)
+	}
+	return secret
+}
+
 // PROTECTED INTERFACE
 
 // Private Methods
 
+func (v *hsmEd25519_) agreementKey() *ecd.PrivateKey {
+	// The agreement key is derived from the seed of the signing key so that
+	// they are generated, rotated and backed up together.
+	var seed = sig.PrivateKey(v.privateKey_).Seed()
+	var digest = dig.Sum512(append([]byte("X25519"), seed...))
+	var privateKey, err = ecd.X25519().NewPrivateKey(digest[:32])
+	if err != nil {
+		panic(err)
+	}
+	return privateKey
+}
+
 func (v *hsmEd25519_) createConfiguration(
 	tag string,
 ) {
@@ -167,6 +313,8 @@ func (v *hsmEd25519_) createConfiguration(
 	v.publicKey_ = nil
 	v.privateKey_ = nil
 	v.previousKey_ = nil
+	v.record_ = nil
+	v.signature_ = nil
 	v.controller_ = uti.Controller(
 		hsmEd25519Class().events_,
 		hsmEd25519Class().transitions_,
@@ -196,6 +344,13 @@ func (v *hsmEd25519_) readConfiguration(
 		"An error occurred while attempting to read in the HSM configuration",
 	)
 
+	// Discard any journal left behind by an interrupted write since the
+	// configuration file itself is always consistent.
+	var journal = v.filename_ + ".journal"
+	if uti.PathExists(journal) {
+		uti.RemovePath(journal)
+	}
+
 	var source = uti.ReadFile(v.filename_)
 	var component = doc.ParseComponent(source)
 
@@ -227,6 +382,18 @@ func (v *hsmEd25519_) readConfiguration(
 		v.previousKey_ = doc.Binary(previousKey).AsIntrinsic()
 	}
 
+	// The rotation record is missing from configurations written by older
+	// versions of this module.
+	var record = component.GetSubcomponent(doc.Symbol("$rotationRecord"))
+	if uti.IsDefined(record) && doc.FormatComponent(record) != "none" {
+		v.record_ = doc.Binary(doc.FormatComponent(record)).AsIntrinsic()
+	}
+
+	var signature = component.GetSubcomponent(doc.Symbol("$rotationSignature"))
+	if uti.IsDefined(signature) && doc.FormatComponent(signature) != "none" {
+		v.signature_ = doc.Binary(doc.FormatComponent(signature)).AsIntrinsic()
+	}
+
 	var state = doc.FormatComponent(
 		component.GetSubcomponent(doc.Symbol("$state")),
 	)
@@ -277,17 +444,34 @@ func (v *hsmEd25519_) writeConfiguration() {
 		previousKey = doc.Binary(v.previousKey_).AsSource()
 	}
 
+	var record = "none"
+	if uti.IsDefined(v.record_) {
+		record = doc.Binary(v.record_).AsSource()
+	}
+
+	var signature = "none"
+	if uti.IsDefined(v.signature_) {
+		signature = doc.Binary(v.signature_).AsSource()
+	}
+
 	var source = `[
     $tag: ` + tag + `
     $state: ` + state + `
     $publicKey: ` + publicKey + `
     $privateKey: ` + privateKey + `
     $previousKey: ` + previousKey + `
+    $rotationRecord: ` + record + `
+    $rotationSignature: ` + signature + `
 ](
     $type: /bali/types/notary/HsmEd25519/v3
 )
 `
-	uti.WriteFile(v.filename_, source)
+
+	// Write the journal first and then atomically replace the configuration
+	// file so that a crash never leaves a partially written configuration.
+	var journal = v.filename_ + ".journal"
+	uti.WriteFile(journal, source)
+	uti.RenamePath(journal, v.filename_)
 }
 
 // Instance Structure
@@ -298,6 +482,8 @@ type hsmEd25519_ struct {
 	publicKey_   []byte
 	privateKey_  []byte
 	previousKey_ []byte
+	record_      []byte
+	signature_   []byte
 	filename_    string
 	controller_  uti.Stateful
 }
@@ -306,15 +492,19 @@ type hsmEd25519_ struct {
 
 type hsmEd25519Class_ struct {
 	// Declare the class constants.
-	algorithm_    string
-	keyless_      uti.State
-	loneKey_      uti.State
-	twoKeys_      uti.State
-	generateKeys_ uti.Event
-	signBytes_    uti.Event
-	rotateKeys_   uti.Event
-	events_       []uti.Event
-	transitions_  map[uti.State]uti.Transitions
+	algorithm_           string
+	keyless_             uti.State
+	loneKey_             uti.State
+	twoKeys_             uti.State
+	generateKeys_        uti.Event
+	signBytes_           uti.Event
+	rotateKeys_          uti.Event
+	signWithPreviousKey_ uti.Event
+	abortRotation_       uti.Event
+	exportKey_           uti.Event
+	importKey_           uti.Event
+	events_              []uti.Event
+	transitions_         map[uti.State]uti.Transitions
 }
 
 // Class Reference
@@ -325,17 +515,38 @@ func hsmEd25519Class() *hsmEd25519Class_ {
 
 var hsmEd25519ClassReference_ = &hsmEd25519Class_{
 	// Initialize the class constants.
-	algorithm_:    "ED25519",
-	keyless_:      "$Keyless",
-	loneKey_:      "$LoneKey",
-	twoKeys_:      "$TwoKeys",
-	generateKeys_: "$GenerateKeys",
-	signBytes_:    "$SignBytes",
-	rotateKeys_:   "$RotateKeys",
-	events_:       []uti.Event{"$GenerateKeys", "$SignBytes", "$RotateKeys"},
+	algorithm_:           "ED25519",
+	keyless_:             "$Keyless",
+	loneKey_:             "$LoneKey",
+	twoKeys_:             "$TwoKeys",
+	generateKeys_:        "$GenerateKeys",
+	signBytes_:           "$SignBytes",
+	rotateKeys_:          "$RotateKeys",
+	signWithPreviousKey_: "$SignWithPreviousKey",
+	abortRotation_:       "$AbortRotation",
+	exportKey_:           "$ExportKey",
+	importKey_:           "$ImportKey",
+	events_: []uti.Event{
+		"$GenerateKeys",
+		"$SignBytes",
+		"$RotateKeys",
+		"$SignWithPreviousKey",
+		"$AbortRotation",
+		"$ExportKey",
+		"$ImportKey",
+	},
 	transitions_: map[uti.State]uti.Transitions{
-		"$Keyless": uti.Transitions{"$LoneKey", "$Invalid", "$Invalid"},
-		"$LoneKey": uti.Transitions{"$Invalid", "$LoneKey", "$TwoKeys"},
-		"$TwoKeys": uti.Transitions{"$Invalid", "$LoneKey", "$Invalid"},
+		"$Keyless": uti.Transitions{
+			"$LoneKey", "$Invalid", "$Invalid", "$Invalid",
+			"$Invalid", "$Invalid", "$LoneKey",
+		},
+		"$LoneKey": uti.Transitions{
+			"$Invalid", "$LoneKey", "$TwoKeys", "$Invalid",
+			"$Invalid", "$LoneKey", "$Invalid",
+		},
+		"$TwoKeys": uti.Transitions{
+			"$Invalid", "$Invalid", "$Invalid", "$LoneKey",
+			"$LoneKey", "$Invalid", "$Invalid",
+		},
 	},
 }
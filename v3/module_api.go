@@ -43,27 +43,98 @@ import (
 // Documents
 
 type (
-	CitationClassLike = com.CitationClassLike
-	ContentClassLike  = com.ContentClassLike
-	DocumentClassLike = com.DocumentClassLike
-	IdentityClassLike = com.IdentityClassLike
-	SealClassLike     = com.SealClassLike
+	Kind = com.Kind
+)
+
+const (
+	AnyKind        = com.AnyKind
+	AttributesKind = com.AttributesKind
+	BinaryKind     = com.BinaryKind
+	DurationKind   = com.DurationKind
+	ItemsKind      = com.ItemsKind
+	MomentKind     = com.MomentKind
+	NameKind       = com.NameKind
+	NumberKind     = com.NumberKind
+	QuoteKind      = com.QuoteKind
+	ResourceKind   = com.ResourceKind
+	TagKind        = com.TagKind
+	VersionKind    = com.VersionKind
+)
+
+type (
+	BundleClassLike           = com.BundleClassLike
+	CanonicalClassLike        = com.CanonicalClassLike
+	CitationClassLike         = com.CitationClassLike
+	ConsistencyProofClassLike = com.ConsistencyProofClassLike
+	ContentClassLike          = com.ContentClassLike
+	DocumentClassLike         = com.DocumentClassLike
+	EnvelopeClassLike         = com.EnvelopeClassLike
+	IdentityClassLike         = com.IdentityClassLike
+	InclusionProofClassLike   = com.InclusionProofClassLike
+	JSONClassLike             = com.JSONClassLike
+	PayloadClassLike          = com.PayloadClassLike
+	PermissionsClassLike      = com.PermissionsClassLike
+	PolicyClassLike           = com.PolicyClassLike
+	SealClassLike             = com.SealClassLike
+	TreeHeadClassLike         = com.TreeHeadClassLike
+	ValidatorClassLike        = com.ValidatorClassLike
 )
 
 type (
-	CitationLike = com.CitationLike
-	ContentLike  = com.ContentLike
-	DocumentLike = com.DocumentLike
-	IdentityLike = com.IdentityLike
-	SealLike     = com.SealLike
+	BundleLike           = com.BundleLike
+	CitationLike         = com.CitationLike
+	ConsistencyProofLike = com.ConsistencyProofLike
+	ContentLike          = com.ContentLike
+	DocumentLike         = com.DocumentLike
+	EnvelopeLike         = com.EnvelopeLike
+	IdentityLike         = com.IdentityLike
+	InclusionProofLike   = com.InclusionProofLike
+	PayloadLike          = com.PayloadLike
+	PermissionsLike      = com.PermissionsLike
+	PolicyLike           = com.PolicyLike
+	SealLike             = com.SealLike
+	TreeHeadLike         = com.TreeHeadLike
+	ValidatorLike        = com.ValidatorLike
 )
 
 type (
 	Parameterized = com.Parameterized
+	Validating    = com.Validating
 )
 
 // Agents
 
+const (
+	LastLevel  = age.LastLevel
+	MajorLevel = age.MajorLevel
+	MinorLevel = age.MinorLevel
+	PatchLevel = age.PatchLevel
+)
+
+type (
+	AuditFileClassLike = age.AuditFileClassLike
+)
+
+type (
+	AuditFileLike = age.AuditFileLike
+)
+
+type (
+	ContentStoreClassLike = age.ContentStoreClassLike
+)
+
+type (
+	ContentStoreLike = age.ContentStoreLike
+)
+
+type (
+	DecisionClassLike = age.DecisionClassLike
+)
+
+type (
+	DecisionLike = age.DecisionLike
+)
+
 type (
 	DigitalNotaryClassLike = age.DigitalNotaryClassLike
 )
@@ -73,14 +144,71 @@ type (
 )
 
 type (
-	Trusted  = age.Trusted
-	Hardened = age.Hardened
+	Trusted       = age.Trusted
+	Hardened      = age.Hardened
+	Agreeing      = age.Agreeing
+	AuditSink     = age.AuditSink
+	Exportable    = age.Exportable
+	Participating = age.Participating
+	Resolving     = age.Resolving
+	Transparent   = age.Transparent
+)
+
+type (
+	GuardClassLike = age.GuardClassLike
+)
+
+type (
+	GuardLike = age.GuardLike
+)
+
+type (
+	HistoryClassLike = age.HistoryClassLike
+)
+
+type (
+	HistoryLike = age.HistoryLike
 )
 
 type (
 	HsmEd25519ClassLike = age.HsmEd25519ClassLike
 )
 
+type (
+	MerkleTreeClassLike = age.MerkleTreeClassLike
+)
+
+type (
+	MerkleTreeLike = age.MerkleTreeLike
+)
+
+type (
+	ParticipantEd25519ClassLike = age.ParticipantEd25519ClassLike
+)
+
+type (
+	ParticipantEd25519Like = age.ParticipantEd25519Like
+)
+
+type (
+	OpenPGPClassLike = age.OpenPGPClassLike
+)
+
+type (
+	ShamirClassLike = age.ShamirClassLike
+)
+
+type (
+	SignerClassLike               = age.SignerClassLike
+	VerifiableCredentialClassLike = age.VerifiableCredentialClassLike
+	X509ClassLike                 = age.X509ClassLike
+)
+
+type (
+	SignerLike = age.SignerLike
+	X509Like   = age.X509Like
+)
+
 type (
 	HsmEd25519Like = age.HsmEd25519Like
 )
@@ -93,14 +221,50 @@ type (
 	SsmSha512Like = age.SsmSha512Like
 )
 
+type (
+	SsmSha3ClassLike = age.SsmSha3ClassLike
+)
+
+type (
+	SsmSha3Like = age.SsmSha3Like
+)
+
+type (
+	ThresholdEd25519ClassLike = age.ThresholdEd25519ClassLike
+)
+
+type (
+	ThresholdEd25519Like = age.ThresholdEd25519Like
+)
+
+type (
+	TransparencyLogClassLike = age.TransparencyLogClassLike
+)
+
+type (
+	TransparencyLogLike = age.TransparencyLogLike
+)
+
 // CLASS ACCESSORS
 
 // Documents
 
+func BundleClass() BundleClassLike {
+	return com.BundleClass()
+}
+
+func CanonicalClass() CanonicalClassLike {
+	return com.CanonicalClass()
+}
+
 func CitationClass() CitationClassLike {
 	return com.CitationClass()
 }
 
+func ConsistencyProofClass() ConsistencyProofClassLike {
+	return com.ConsistencyProofClass()
+}
+
 func ContentClass() ContentClassLike {
 	return com.ContentClass()
 }
@@ -109,20 +273,132 @@ func DocumentClass() DocumentClassLike {
 	return com.DocumentClass()
 }
 
+func EnvelopeClass() EnvelopeClassLike {
+	return com.EnvelopeClass()
+}
+
 func IdentityClass() IdentityClassLike {
 	return com.IdentityClass()
 }
 
+func InclusionProofClass() InclusionProofClassLike {
+	return com.InclusionProofClass()
+}
+
+func JSONClass() JSONClassLike {
+	return com.JSONClass()
+}
+
+func PayloadClass() PayloadClassLike {
+	return com.PayloadClass()
+}
+
+func PermissionsClass() PermissionsClassLike {
+	return com.PermissionsClass()
+}
+
+func PolicyClass() PolicyClassLike {
+	return com.PolicyClass()
+}
+
 func SealClass() SealClassLike {
 	return com.SealClass()
 }
 
+func TreeHeadClass() TreeHeadClassLike {
+	return com.TreeHeadClass()
+}
+
+func ValidatorClass() ValidatorClassLike {
+	return com.ValidatorClass()
+}
+
 // Agents
 
+func AuditFileClass() AuditFileClassLike {
+	return age.AuditFileClass()
+}
+
+func AuditFile(
+	notary DigitalNotaryLike,
+	directory string,
+) AuditFileLike {
+	return AuditFileClass().AuditFile(
+		notary,
+		directory,
+	)
+}
+
+func ContentStoreClass() ContentStoreClassLike {
+	return age.ContentStoreClass()
+}
+
+func ContentStore(
+	ssm Trusted,
+	directory string,
+) ContentStoreLike {
+	return ContentStoreClass().ContentStore(
+		ssm,
+		directory,
+	)
+}
+
+func DecisionClass() DecisionClassLike {
+	return age.DecisionClass()
+}
+
+func Decision(
+	notary DigitalNotaryLike,
+	policy PolicyLike,
+	document DocumentLike,
+	certificates []DocumentLike,
+	moment doc.MomentLike,
+) DecisionLike {
+	return DecisionClass().Decision(
+		notary,
+		policy,
+		document,
+		certificates,
+		moment,
+	)
+}
+
 func DigitalNotaryClass() DigitalNotaryClassLike {
 	return age.DigitalNotaryClass()
 }
 
+func GuardClass() GuardClassLike {
+	return age.GuardClass()
+}
+
+func Guard(
+	notary DigitalNotaryLike,
+	repository Resolving,
+	certificates []DocumentLike,
+) GuardLike {
+	return GuardClass().Guard(
+		notary,
+		repository,
+		certificates,
+	)
+}
+
+func HistoryClass() HistoryClassLike {
+	return age.HistoryClass()
+}
+
+func History(
+	notary DigitalNotaryLike,
+	repository Resolving,
+	document DocumentLike,
+) HistoryLike {
+	return HistoryClass().History(
+		notary,
+		repository,
+		document,
+	)
+}
+
 func HsmEd25519Class() HsmEd25519ClassLike {
 	return age.HsmEd25519Class()
 }
@@ -137,6 +413,68 @@ func HsmEd25519(
 	)
 }
 
+func MerkleTreeClass() MerkleTreeClassLike {
+	return age.MerkleTreeClass()
+}
+
+func MerkleTree(
+	ssm Trusted,
+) MerkleTreeLike {
+	return MerkleTreeClass().MerkleTree(
+		ssm,
+	)
+}
+
+func ParticipantEd25519Class() ParticipantEd25519ClassLike {
+	return age.ParticipantEd25519Class()
+}
+
+func ParticipantEd25519(
+	directory string,
+	identifier uint,
+) ParticipantEd25519Like {
+	return ParticipantEd25519Class().ParticipantEd25519(
+		directory,
+		identifier,
+	)
+}
+
+func OpenPGPClass() OpenPGPClassLike {
+	return age.OpenPGPClass()
+}
+
+func ShamirClass() ShamirClassLike {
+	return age.ShamirClass()
+}
+
+func VerifiableCredentialClass() VerifiableCredentialClassLike {
+	return age.VerifiableCredentialClass()
+}
+
+func SignerClass() SignerClassLike {
+	return age.SignerClass()
+}
+
+func Signer(
+	hsm Hardened,
+) SignerLike {
+	return SignerClass().Signer(
+		hsm,
+	)
+}
+
+func X509Class() X509ClassLike {
+	return age.X509Class()
+}
+
+func X509(
+	arc []uint,
+) X509Like {
+	return X509Class().X509(
+		arc,
+	)
+}
+
 func SsmSha512Class() SsmSha512ClassLike {
 	return age.SsmSha512Class()
 }
@@ -145,6 +483,44 @@ func SsmSha512() SsmSha512Like {
 	return SsmSha512Class().SsmSha512()
 }
 
+func SsmSha3Class() SsmSha3ClassLike {
+	return age.SsmSha3Class()
+}
+
+func SsmSha3() SsmSha3Like {
+	return SsmSha3Class().SsmSha3()
+}
+
+func ThresholdEd25519Class() ThresholdEd25519ClassLike {
+	return age.ThresholdEd25519Class()
+}
+
+func ThresholdEd25519(
+	threshold uint,
+	participants []Participating,
+) ThresholdEd25519Like {
+	return ThresholdEd25519Class().ThresholdEd25519(
+		threshold,
+		participants,
+	)
+}
+
+func TransparencyLogClass() TransparencyLogClassLike {
+	return age.TransparencyLogClass()
+}
+
+func TransparencyLog(
+	notary DigitalNotaryLike,
+	ssm Trusted,
+	directory string,
+) TransparencyLogLike {
+	return TransparencyLogClass().TransparencyLog(
+		notary,
+		ssm,
+		directory,
+	)
+}
+
 // GLOBAL FUNCTIONS
 
 // Agents
@@ -152,8 +528,8 @@ func SsmSha512() SsmSha512Like {
 func DigitalNotary(
 	value ...any,
 ) DigitalNotaryLike {
-	var ssm = value[0].(SsmSha512Like)
-	var hsm = value[1].(HsmEd25519Like)
+	var ssm = value[0].(Trusted)
+	var hsm = value[1].(Hardened)
 	var notary DigitalNotaryLike
 	switch len(value) {
 	case 2:
@@ -168,6 +544,15 @@ func DigitalNotary(
 			hsm,
 			certificate,
 		)
+	case 4:
+		var certificate = value[2].(DocumentLike)
+		var repository = value[3].(Resolving)
+		notary = DigitalNotaryClass().DigitalNotaryWithRepository(
+			ssm,
+			hsm,
+			certificate,
+			repository,
+		)
 	default:
 		panic("An invalid number of arguments was passed into the DigitalNotary contructor")
 	}
@@ -176,6 +561,34 @@ func DigitalNotary(
 
 // Documents
 
+func Bundle(
+	value ...any,
+) BundleLike {
+	if len(value) == 1 {
+		var source string
+		switch actual := value[0].(type) {
+		case string:
+			source = actual
+		case com.Parameterized:
+			source = actual.AsSource()
+		}
+		return com.BundleClass().BundleFromSource(source)
+	}
+	var document = value[0].(DocumentLike)
+	var tag = value[1].(doc.TagLike)
+	var version = value[2].(doc.VersionLike)
+	var previous doc.ResourceLike
+	if uti.IsDefined(value[3]) {
+		previous = value[3].(doc.ResourceLike)
+	}
+	return BundleClass().Bundle(
+		document,
+		tag,
+		version,
+		previous,
+	)
+}
+
 func Citation(
 	value ...any,
 ) CitationLike {
@@ -201,6 +614,25 @@ func Citation(
 	return CitationClass().Citation(tag, version, algorithm, digest)
 }
 
+func ConsistencyProof(
+	value ...any,
+) ConsistencyProofLike {
+	if len(value) == 1 {
+		var source = value[0].(string)
+		return com.ConsistencyProofClass().ConsistencyProofFromSource(source)
+	}
+	var algorithm = value[0].(doc.QuoteLike)
+	var firstSize = value[1].(uint)
+	var secondSize = value[2].(uint)
+	var path = value[3].([]doc.BinaryLike)
+	return ConsistencyProofClass().ConsistencyProof(
+		algorithm,
+		firstSize,
+		secondSize,
+		path,
+	)
+}
+
 func Content(
 	value ...any,
 ) ContentLike {
@@ -245,6 +677,19 @@ func Document(
 	}
 }
 
+func Envelope(
+	value ...any,
+) EnvelopeLike {
+	if len(value) == 1 {
+		var source = value[0].(string)
+		return com.EnvelopeClass().EnvelopeFromSource(source)
+	}
+	var algorithm = value[0].(doc.QuoteLike)
+	var ephemeralKey = value[1].(doc.BinaryLike)
+	var ciphertext = value[2].(doc.BinaryLike)
+	return EnvelopeClass().Envelope(algorithm, ephemeralKey, ciphertext)
+}
+
 func Identity(
 	value ...any,
 ) IdentityLike {
@@ -277,6 +722,111 @@ func Identity(
 	)
 }
 
+func InclusionProof(
+	value ...any,
+) InclusionProofLike {
+	if len(value) == 1 {
+		var source = value[0].(string)
+		return com.InclusionProofClass().InclusionProofFromSource(source)
+	}
+	var algorithm = value[0].(doc.QuoteLike)
+	var index = value[1].(uint)
+	var size = value[2].(uint)
+	var path = value[3].([]doc.BinaryLike)
+	var treeHead = value[4].(doc.ResourceLike)
+	return InclusionProofClass().InclusionProof(
+		algorithm,
+		index,
+		size,
+		path,
+		treeHead,
+	)
+}
+
+func Payload(
+	value ...any,
+) PayloadLike {
+	if len(value) == 1 {
+		var source string
+		switch actual := value[0].(type) {
+		case string:
+			source = actual
+		case com.Parameterized:
+			source = actual.AsSource()
+		}
+		return com.PayloadClass().PayloadFromSource(source)
+	}
+	var protected = value[0].(doc.BinaryLike)
+	var payload = value[1].(doc.BinaryLike)
+	var tag = value[2].(doc.TagLike)
+	var version = value[3].(doc.VersionLike)
+	var previous doc.ResourceLike
+	if uti.IsDefined(value[4]) {
+		previous = value[4].(doc.ResourceLike)
+	}
+	return PayloadClass().Payload(
+		protected,
+		payload,
+		tag,
+		version,
+		previous,
+	)
+}
+
+func Permissions(
+	value ...any,
+) PermissionsLike {
+	if len(value) == 1 {
+		var source string
+		switch actual := value[0].(type) {
+		case string:
+			source = actual
+		case com.Parameterized:
+			source = actual.AsSource()
+		}
+		return com.PermissionsClass().PermissionsFromSource(source)
+	}
+	var name = value[0].(doc.NameLike)
+	var tag = value[1].(doc.TagLike)
+	var version = value[2].(doc.VersionLike)
+	var previous doc.ResourceLike
+	if uti.IsDefined(value[3]) {
+		previous = value[3].(doc.ResourceLike)
+	}
+	return PermissionsClass().Permissions(
+		name,
+		tag,
+		version,
+		previous,
+	)
+}
+
+func Policy(
+	value ...any,
+) PolicyLike {
+	if len(value) == 1 {
+		var source string
+		switch actual := value[0].(type) {
+		case string:
+			source = actual
+		case com.Parameterized:
+			source = actual.AsSource()
+		}
+		return com.PolicyClass().PolicyFromSource(source)
+	}
+	var tag = value[0].(doc.TagLike)
+	var version = value[1].(doc.VersionLike)
+	var previous doc.ResourceLike
+	if uti.IsDefined(value[2]) {
+		previous = value[2].(doc.ResourceLike)
+	}
+	return PolicyClass().Policy(
+		tag,
+		version,
+		previous,
+	)
+}
+
 func Seal(
 	value ...any,
 ) SealLike {
@@ -286,5 +836,47 @@ func Seal(
 	}
 	var algorithm = value[0].(doc.QuoteLike)
 	var signature = value[1].(doc.BinaryLike)
+	if len(value) > 2 && uti.IsDefined(value[2]) {
+		var format = value[2].(doc.NameLike)
+		return SealClass().SealWithFormat(algorithm, signature, format)
+	}
 	return SealClass().Seal(algorithm, signature)
 }
+
+func TreeHead(
+	value ...any,
+) TreeHeadLike {
+	if len(value) == 1 {
+		var source string
+		switch actual := value[0].(type) {
+		case string:
+			source = actual
+		case com.Parameterized:
+			source = actual.AsSource()
+		}
+		return com.TreeHeadClass().TreeHeadFromSource(source)
+	}
+	var algorithm = value[0].(doc.QuoteLike)
+	var size = value[1].(uint)
+	var root = value[2].(doc.BinaryLike)
+	var tag = value[3].(doc.TagLike)
+	var version = value[4].(doc.VersionLike)
+	var previous doc.ResourceLike
+	if uti.IsDefined(value[5]) {
+		previous = value[5].(doc.ResourceLike)
+	}
+	return TreeHeadClass().TreeHead(
+		algorithm,
+		size,
+		root,
+		tag,
+		version,
+		previous,
+	)
+}
+
+func Validator(
+	component doc.Composite,
+) ValidatorLike {
+	return ValidatorClass().Validator(component)
+}
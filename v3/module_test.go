@@ -13,12 +13,29 @@
 package module_test
 
 import (
+	sig "crypto/ed25519"
+	ran "crypto/rand"
+	sha "crypto/sha512"
+	x50 "crypto/x509"
+	b64 "encoding/base64"
+	hex "encoding/hex"
+	jsn "encoding/json"
 	fmt "fmt"
 	doc "github.com/bali-nebula/go-bali-documents/v3"
 	not "github.com/bali-nebula/go-digital-notary/v3"
+	nts "github.com/bali-nebula/go-digital-notary/v3/notarytest"
+	ser "github.com/bali-nebula/go-digital-notary/v3/server"
 	uti "github.com/craterdog/go-essential-utilities/v8"
 	ass "github.com/stretchr/testify/assert"
+	iox "io"
+	big "math/big"
+	hts "net/http"
+	htt "net/http/httptest"
+	osx "os"
+	exe "os/exec"
+	sts "strings"
 	tes "testing"
+	time "time"
 )
 
 const testDirectory = "./test/"
@@ -45,6 +62,77 @@ func TestParsingCitations(t *tes.T) {
 	ass.Equal(t, source, formatted)
 }
 
+func TestCitationResources(t *tes.T) {
+	// Citations with any digest length round-trip through their resources.
+	var tag = doc.Tag()
+	var version = doc.Version("v1.2")
+	var algorithm = doc.Quote(`"SHA3-256"`)
+	var bytes = ssm.DigestBytes([]byte("digest"))
+	bytes = append(bytes, bytes...)
+	for _, size := range []int{1, 2, 3, 20, 32, 45, 48, 64, 128} {
+		var digest = doc.Binary(bytes[:size])
+		var citation = not.Citation(tag, version, algorithm, digest)
+		var resource = citation.AsResource()
+		var parsed = not.Citation(resource)
+		ass.Equal(t, citation.AsSource(), parsed.AsSource())
+		ass.Equal(t, resource.AsSource(), parsed.AsResource().AsSource())
+	}
+
+	// Invalid resources result in descriptive panics.
+	var resources = map[string]string{
+		"<https:/ABC:v1?SHA512=AAAA>":  "it must begin with",
+		"<nebula:/ABC:v1>":             "it is missing the",
+		"<nebula:/ABE:v1?SHA512=AAAA>": "the tag must contain",
+		"<nebula:/ABC:1?SHA512=AAAA>":  "the version must have",
+		"<nebula:/ABC:v1?sha512=AAAA>": "the algorithm must be",
+		"<nebula:/ABC:v1?SHA512=AA+A>": "the digest is not valid",
+		"<nebula:/ABC:v1?SHA512=A>":    "the digest is not valid",
+	}
+	for source, reason := range resources {
+		func() {
+			defer func() {
+				var message = recover().(string)
+				ass.True(t, sts.Contains(message, reason), message)
+			}()
+			not.Citation(doc.Resource(source))
+		}()
+	}
+}
+
+func TestSchemaViolations(t *tes.T) {
+	// Valid components have no violations.
+	var source = uti.ReadFile(testDirectory + "components/Document.bali")
+	var component = doc.ParseComponent(source)
+	ass.Empty(t, not.DocumentClass().Violations(component))
+
+	// Malformed components report every violation.
+	source = sts.Replace(source, "$digest", "$hash", 1)
+	source = sts.Replace(source, "$owner: #", "$owner: /", 1)
+	source = sts.Replace(source, "B2gplFfh", "", 1)
+	component = doc.ParseComponent(source)
+	ass.Equal(
+		t,
+		[]string{
+			"$notaries[1]: The $owner attribute must be a tag value.",
+			"$notaries[1]: $citation: The $digest attribute is missing.",
+			"$notaries[1]: $seal: The $signature attribute must contain 64 bytes for the ED25519 algorithm, not 58.",
+		},
+		not.DocumentClass().Violations(component),
+	)
+	ass.Panics(t, func() { not.Document(source) })
+
+	// Components of the wrong type are rejected at the boundary.
+	source = uti.ReadFile(testDirectory + "components/Citation.bali")
+	ass.PanicsWithValue(
+		t,
+		"The source is not a valid /bali/types/notary/Seal/v3 document:\n"+
+			"    The $type parameter must be /bali/types/notary/Seal/v3, not /bali/types/notary/Citation/v3.\n"+
+			"    The $algorithm attribute must be a quote value.\n"+
+			"    The $signature attribute is missing.",
+		func() { not.Seal(sts.Replace(source, `"SHA512"`, "$SHA512", 1)) },
+	)
+}
+
 func TestParsingContents(t *tes.T) {
 	var filename = testDirectory + "components/Content.bali"
 	fmt.Println(filename)
@@ -136,11 +224,167 @@ func TestHSM(t *tes.T) {
 	var signature = hsm.SignBytes(bytes)
 	ass.True(t, hsm.IsValid(publicKey, bytes, signature))
 	var newPublicKey = hsm.RotateKeys()
-	signature = hsm.SignBytes(newPublicKey)
+	signature = hsm.SignWithPreviousKey(newPublicKey)
 	ass.True(t, hsm.IsValid(publicKey, newPublicKey, signature))
 	hsm.EraseKeys()
 }
 
+func TestHsmKeyRotation(t *tes.T) {
+	var bytes = []byte{0x0, 0x1, 0x2, 0x3, 0x4}
+	hsm.EraseKeys()
+	var previousKey = hsm.GenerateKeys()
+	var currentKey = hsm.RotateKeys()
+	ass.NotEqual(t, previousKey, currentKey)
+	ass.Equal(t, currentKey, hsm.GetPublicKey())
+
+	// Only the previous key may be used while a rotation is pending.
+	ass.Panics(t, func() { hsm.SignBytes(bytes) })
+	ass.Panics(t, func() { hsm.RotateKeys() })
+	var signature = hsm.SignWithPreviousKey(currentKey)
+	ass.True(t, hsm.IsValid(previousKey, currentKey, signature))
+	ass.False(t, hsm.IsValid(currentKey, currentKey, signature))
+
+	// The previous key may only be used once.
+	ass.Panics(t, func() { hsm.SignWithPreviousKey(bytes) })
+	signature = hsm.SignBytes(bytes)
+	ass.True(t, hsm.IsValid(currentKey, bytes, signature))
+	hsm.EraseKeys()
+}
+
+func TestTrustedConformance(t *tes.T) {
+	nts.RunTrustedConformance(t, func() not.Trusted {
+		return not.SsmSha512()
+	})
+}
+
+func TestHardenedConformance(t *tes.T) {
+	nts.RunHardenedConformance(t, func() not.Hardened {
+		return HsmEd25519TestClass().HsmEd25519(device, secret)
+	})
+}
+
+func TestShamir(t *tes.T) {
+	var secret = []byte("This is a very secret message!")
+	var shares = not.ShamirClass().SplitSecret(secret, 3, 5)
+	ass.Equal(t, 5, len(shares))
+	ass.Equal(t, secret, not.ShamirClass().CombineShares(shares))
+	ass.Equal(t, secret, not.ShamirClass().CombineShares(shares[2:]))
+	ass.Equal(t, secret, not.ShamirClass().CombineShares(
+		[][]byte{shares[3], shares[0], shares[1]},
+	))
+	ass.NotEqual(t, secret, not.ShamirClass().CombineShares(shares[:2]))
+	ass.Panics(t, func() { not.ShamirClass().SplitSecret(secret, 1, 5) })
+	ass.Panics(t, func() { not.ShamirClass().SplitSecret(secret, 6, 5) })
+	ass.Panics(t, func() {
+		not.ShamirClass().CombineShares([][]byte{shares[0], shares[0]})
+	})
+}
+
+func thresholdHsm() not.ThresholdEd25519Like {
+	var directory = testDirectory + "threshold"
+	var participants = []not.Participating{
+		not.ParticipantEd25519(directory, 1),
+		not.ParticipantEd25519(directory, 2),
+		not.ParticipantEd25519(directory, 3),
+	}
+	return not.ThresholdEd25519(2, participants)
+}
+
+func TestThresholdConformance(t *tes.T) {
+	nts.RunHardenedConformance(t, func() not.Hardened {
+		return thresholdHsm()
+	})
+}
+
+func TestThresholdNotary(t *tes.T) {
+	// Generate a certificate using the threshold HSM.
+	var threshold = thresholdHsm()
+	var notary = not.DigitalNotary(ssm, threshold)
+	var attributes = identity.GetAttributes()
+	var certificateV1 = notary.GenerateKey(attributes)
+	var certificateV2 = notary.RefreshKey()
+
+	// Any other threshold number of distinct participants may sign.
+	ass.Equal(t, []uint{1, 2}, threshold.GetSigners())
+	ass.Panics(t, func() { threshold.SetSigners([]uint{3}) })
+	ass.Panics(t, func() { threshold.SetSigners([]uint{3, 3}) })
+	ass.Panics(t, func() { threshold.SetSigners([]uint{2, 4}) })
+	threshold.SetSigners([]uint{3, 2})
+	ass.Equal(t, []uint{2, 3}, threshold.GetSigners())
+	var document = not.Document(not.Content(
+		doc.ParseComponent(`[ $status: $Draft ]`),
+		doc.Name("/bali/examples/Report/v1"),
+		doc.Tag(),
+		doc.Version("v1"),
+		doc.Name("/bali/permissions/Private/v3"),
+		nil,
+	))
+	notary.NotarizeDocument(document)
+	ass.True(t, notary.SealMatches(document, certificateV2))
+	var certificateV3 = notary.RefreshKey()
+
+	// The seals are standard ED25519 seals.
+	notary = not.DigitalNotary(ssm, hsm)
+	ass.True(t, notary.SealMatches(certificateV1, certificateV1))
+	ass.True(t, notary.SealMatches(certificateV2, certificateV1))
+	ass.True(t, notary.SealMatches(certificateV3, certificateV2))
+	var seal = certificateV2.RemoveNotarySeal()
+	var bytes = certificateV2.AsCanonical()
+	certificateV2.SetNotarySeal(seal)
+	var key = not.Identity(certificateV1.GetContent()).GetKey().AsIntrinsic()
+	ass.True(t, sig.Verify(key, bytes, seal.GetSignature().AsIntrinsic()))
+	threshold.EraseKeys()
+}
+
+func TestThresholdParticipants(t *tes.T) {
+	var directory = testDirectory + "participants"
+	uti.RemovePath(directory)
+	var first = not.ParticipantEd25519(directory, 1)
+	var second = not.ParticipantEd25519(directory, 2)
+	var packages = [][]byte{
+		first.GenerateCommitments(2),
+		second.GenerateCommitments(2),
+	}
+
+	var shares = [][]byte{first.GenerateShare(1), second.GenerateShare(1)}
+	var others = [][]byte{first.GenerateShare(2), second.GenerateShare(2)}
+
+	// Each sender, including the recipient itself, must be included once.
+	ass.Panics(t, func() {
+		first.CompleteKeys(
+			[][]byte{packages[0], packages[0]},
+			[][]byte{shares[0], shares[0]},
+		)
+	})
+	ass.Panics(t, func() {
+		var unnamed = append([]byte{0, 0, 0, 0}, packages[1][4:]...)
+		first.CompleteKeys(
+			[][]byte{packages[0], unnamed},
+			[][]byte{shares[0], shares[1]},
+		)
+	})
+	ass.Panics(t, func() {
+		second.CompleteKeys(packages[:1], others[:1])
+	})
+	var groupKey = first.CompleteKeys(packages, shares)
+	ass.Equal(t, groupKey, second.CompleteKeys(packages, others))
+
+	// A participant only signs using a commitment to its current nonces.
+	var message = []byte("A message to be signed.")
+	var stale = first.CommitNonces()
+	var commitments = [][]byte{first.CommitNonces(), second.CommitNonces()}
+	ass.Panics(t, func() {
+		first.SignShare(message, [][]byte{stale, commitments[1]}, false)
+	})
+	ass.Panics(t, func() {
+		second.SignShare(message, [][]byte{commitments[0]}, false)
+	})
+	commitments = [][]byte{first.CommitNonces(), second.CommitNonces()}
+	ass.NotEmpty(t, first.SignShare(message, commitments, false))
+	ass.NotEmpty(t, second.SignShare(message, commitments, false))
+	uti.RemovePath(directory)
+}
+
 var notary not.DigitalNotaryLike
 
 func TestDigitalNotaryInitialization(t *tes.T) {
@@ -182,6 +426,7 @@ func TestDigitalNotaryGenerateKey(t *tes.T) {
 
 func TestDigitalNotaryLifecycle(t *tes.T) {
 	// Generate and validate a new public-private key pair.
+	uti.MakeDirectory(testDirectory + "agents/")
 	notary.ForgetKey()
 	var attributes = identity.GetAttributes()
 	var document = notary.GenerateKey(attributes)
@@ -250,3 +495,1577 @@ func TestDigitalNotaryLifecycle(t *tes.T) {
 	source = document.AsSource()
 	uti.WriteFile(filename, source)
 }
+
+type repository_ struct {
+	documents_ []not.DocumentLike
+}
+
+func (v *repository_) RetrieveDocument(
+	citation not.CitationLike,
+) not.DocumentLike {
+	for _, document := range v.documents_ {
+		var content = document.GetContent()
+		if content.GetTag().AsSource() == citation.GetTag().AsSource() &&
+			content.GetVersion().AsSource() == citation.GetVersion().AsSource() {
+			return document
+		}
+	}
+	return nil
+}
+
+func (v *repository_) RetrieveVersions(
+	tag doc.TagLike,
+) []not.DocumentLike {
+	var versions []not.DocumentLike
+	for _, document := range v.documents_ {
+		if document.GetContent().GetTag().AsSource() == tag.AsSource() {
+			versions = append(versions, document)
+		}
+	}
+	return versions
+}
+
+type crashingHsm_ struct {
+	not.Hardened
+	signs_ bool
+}
+
+func (v *crashingHsm_) SignWithPreviousKey(
+	bytes []byte,
+) []byte {
+	// Simulate a crash either before or after the previous key is used.
+	if v.signs_ {
+		v.Hardened.SignWithPreviousKey(bytes)
+	}
+	panic("The security module crashed.")
+}
+
+func TestDigitalNotaryRecovery(t *tes.T) {
+	// Generate a new certificate.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificateV1 = notary.GenerateKey(attributes)
+	var repository = &repository_{documents_: []not.DocumentLike{certificateV1}}
+
+	// Recovery is not needed without a pending key rotation.
+	ass.Equal(t, certificateV1, notary.Recover())
+
+	// A rotation interrupted before the new certificate is recorded is rolled
+	// back.
+	var previousKey = hsm.GetPublicKey()
+	hsm.RotateKeys()
+	hsm = HsmEd25519TestClass().HsmEd25519(device, secret)
+	notary = not.DigitalNotary(ssm, hsm, certificateV1, repository)
+	ass.Equal(t, certificateV1, notary.Recover())
+	ass.Equal(t, previousKey, hsm.GetPublicKey())
+	ass.Empty(t, hsm.GetPreviousKey())
+
+	// A rotation interrupted before the new certificate is signed is completed.
+	notary = not.DigitalNotary(ssm, &crashingHsm_{Hardened: hsm}, certificateV1, repository)
+	ass.Panics(t, func() { notary.RefreshKey() })
+	hsm = HsmEd25519TestClass().HsmEd25519(device, secret)
+	notary = not.DigitalNotary(ssm, hsm, certificateV1, repository)
+	var certificateV2 = notary.Recover()
+	ass.True(t, notary.SealMatches(certificateV2, certificateV1))
+	var identityV2 = not.Identity(certificateV2.GetContent())
+	ass.Equal(t, hsm.GetPublicKey(), identityV2.GetKey().AsIntrinsic())
+	ass.Equal(t, "v2", identityV2.GetVersion().AsSource())
+	ass.Empty(t, hsm.GetPreviousKey())
+	repository.documents_ = append(repository.documents_, certificateV2)
+
+	// A rotation interrupted after the new certificate is signed is completed
+	// using the recorded signature.
+	notary = not.DigitalNotary(ssm, &crashingHsm_{Hardened: hsm, signs_: true}, certificateV2, repository)
+	ass.Panics(t, func() { notary.RefreshKey() })
+	hsm = HsmEd25519TestClass().HsmEd25519(device, secret)
+	ass.Empty(t, hsm.GetPreviousKey())
+	notary = not.DigitalNotary(ssm, hsm, certificateV2, repository)
+	var certificateV3 = notary.Recover()
+	ass.True(t, notary.SealMatches(certificateV3, certificateV2))
+	var identityV3 = not.Identity(certificateV3.GetContent())
+	ass.Equal(t, hsm.GetPublicKey(), identityV3.GetKey().AsIntrinsic())
+	ass.Equal(t, "v3", identityV3.GetVersion().AsSource())
+	repository.documents_ = append(repository.documents_, certificateV3)
+
+	// The notary can be restarted using the new certificate, but only if each
+	// of its previous versions can be verified.
+	hsm = HsmEd25519TestClass().HsmEd25519(device, secret)
+	notary = not.DigitalNotary(ssm, hsm, certificateV3, repository)
+	ass.Equal(t, certificateV3.AsSource(), notary.Recover().AsSource())
+	ass.Panics(t, func() {
+		not.DigitalNotary(ssm, hsm, certificateV3, &repository_{documents_: []not.DocumentLike{certificateV1}})
+	})
+	ass.Panics(t, func() {
+		not.DigitalNotary(ssm, hsm, certificateV2, repository)
+	})
+
+	// Without a repository only a self-signed certificate can be validated.
+	ass.Panics(t, func() {
+		not.DigitalNotary(ssm, hsm, certificateV3)
+	})
+	var certificateV4 = notary.RefreshKey()
+	ass.True(t, notary.SealMatches(certificateV4, certificateV3))
+	notary.ForgetKey()
+}
+
+func TestDigitalNotaryBackup(t *tes.T) {
+	// Generate a new certificate and back up its private key.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificate = notary.GenerateKey(attributes)
+	var shares = notary.BackupKey(3, 5)
+	ass.Equal(t, 5, len(shares))
+	for _, share := range shares {
+		ass.True(t, notary.SealMatches(share, certificate))
+	}
+
+	// Too few shares cannot restore the private key.
+	notary = not.DigitalNotary(ssm, hsm)
+	ass.Panics(t, func() {
+		notary.RestoreKey(shares[1:3], certificate)
+	})
+
+	// A share that has been altered since it was notarized is rejected.
+	var altered = not.Document(sts.Replace(
+		shares[3].AsSource(),
+		"$threshold: 3",
+		"$threshold: 2",
+		1,
+	))
+	func() {
+		defer func() {
+			var message = fmt.Sprint(recover())
+			ass.True(t, sts.Contains(message, "does not belong"), message)
+		}()
+		notary = not.DigitalNotary(ssm, hsm)
+		notary.RestoreKey([]not.DocumentLike{shares[4], altered, shares[2]}, certificate)
+	}()
+
+	// Any three shares restore the private key.
+	notary = not.DigitalNotary(ssm, hsm)
+	notary.RestoreKey([]not.DocumentLike{shares[4], shares[0], shares[2]}, certificate)
+	var document = notary.GenerateCredential(doc.Moment())
+	ass.True(t, notary.SealMatches(document, certificate))
+	notary.ForgetKey()
+}
+
+func TestCanonicalSeals(t *tes.T) {
+	// The canonical form ignores the formatting of the source.
+	var filename = testDirectory + "components/Document.bali"
+	var source = uti.ReadFile(filename)
+	var document = not.Document(source)
+	var reformatted = not.Document(sts.ReplaceAll(source, "    ", "  "))
+	ass.NotEqual(t, document.AsSource(), sts.ReplaceAll(source, "    ", "  "))
+	ass.Equal(t, document.AsCanonical(), reformatted.AsCanonical())
+
+	// Primitive values are encoded independently of their source.
+	var canonical = not.CanonicalClass()
+	var first = doc.ParseComponent(`[
+    $narrative: ">
+        Hello
+            World!
+    <"
+]`)
+	var second = doc.ParseComponent(`[
+    $narrative: ">
+    Hello
+        World!
+<"
+]`)
+	ass.Equal(t, canonical.CanonicalBytes(first), canonical.CanonicalBytes(second))
+	ass.NotEqual(
+		t,
+		canonical.CanonicalBytes(first),
+		canonical.CanonicalBytes(doc.ParseComponent(`[ $narrative: "Hello World!" ]`)),
+	)
+	ass.Panics(t, func() {
+		canonical.CanonicalBytes(doc.ParseComponent(`[ $procedure: { return none } ]`))
+	})
+
+	// New seals record the canonical format.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificate = notary.GenerateKey(attributes)
+	var credential = notary.GenerateCredential(doc.Moment())
+	var seal = credential.RemoveNotarySeal()
+	ass.Equal(t, not.CanonicalClass().Format(), seal.GetOptionalFormat())
+	credential.SetNotarySeal(seal)
+	ass.True(t, notary.SealMatches(credential, certificate))
+
+	// The format is covered by the signature.
+	seal = credential.RemoveNotarySeal()
+	var relabeled = not.Seal(seal.GetAlgorithm(), seal.GetSignature(), canonical.LegacyFormat())
+	credential.SetNotarySeal(relabeled)
+	ass.False(t, notary.SealMatches(credential, certificate))
+
+	// Seals using the first canonical format remain verifiable.
+	credential.RemoveNotarySeal()
+	var bytes = canonical.FormatBytes(credential.AsIntrinsic(), canonical.LegacyFormat())
+	credential.SetNotarySeal(not.Seal(
+		seal.GetAlgorithm(),
+		doc.Binary(hsm.SignBytes(bytes)),
+		canonical.LegacyFormat(),
+	))
+	ass.True(t, notary.SealMatches(credential, certificate))
+
+	// Legacy seals over the formatted source remain verifiable.
+	seal = credential.RemoveNotarySeal()
+	var signature = hsm.SignBytes([]byte(credential.AsSource()))
+	seal = not.Seal(seal.GetAlgorithm(), doc.Binary(signature))
+	ass.Nil(t, seal.GetOptionalFormat())
+	credential.SetNotarySeal(seal)
+	ass.True(t, notary.SealMatches(credential, certificate))
+
+	// Legacy citations over the formatted source remain verifiable.
+	var citation = notary.CiteDocument(certificate)
+	ass.True(t, notary.CitationMatches(citation, certificate))
+	var digest = ssm.DigestBytes(
+		canonical.FormatBytes(certificate.AsIntrinsic(), canonical.LegacyFormat()),
+	)
+	citation = not.Citation(
+		citation.GetTag(),
+		citation.GetVersion(),
+		citation.GetAlgorithm(),
+		doc.Binary(digest),
+	)
+	ass.True(t, notary.CitationMatches(citation, certificate))
+	digest = ssm.DigestBytes([]byte(certificate.AsSource()))
+	citation = not.Citation(
+		citation.GetTag(),
+		citation.GetVersion(),
+		citation.GetAlgorithm(),
+		doc.Binary(digest),
+	)
+	ass.True(t, notary.CitationMatches(citation, certificate))
+	notary.ForgetKey()
+}
+
+func TestHistory(t *tes.T) {
+	// Create a chain of credential versions.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	notary.GenerateKey(attributes)
+	var v1 = notary.GenerateCredential(doc.Moment())
+	var v2 = notary.RefreshCredential(doc.Moment(), v1)
+	var v3 = notary.RefreshCredential(doc.Moment(), v2)
+	var repository = &repository_{
+		documents_: []not.DocumentLike{v3, v1, v2},
+	}
+
+	// The history is ordered from the first version.
+	var history = not.History(notary, repository, v3)
+	ass.True(t, history.IsValid())
+	ass.Equal(t, []not.DocumentLike{v1, v2, v3}, history.GetVersions())
+
+	// A single successor continues the history.
+	history = not.History(notary, repository, v2)
+	ass.True(t, history.IsValid())
+	ass.Equal(t, 2, len(history.GetVersions()))
+
+	// Another document claiming the same previous version forks the history.
+	var fork = notary.RefreshCredential(doc.Moment(), v1)
+	repository.documents_ = append(repository.documents_, fork)
+	history = not.History(notary, repository, v3)
+	ass.False(t, history.IsValid())
+	ass.Equal(t, []not.DocumentLike{fork}, history.GetForks())
+	ass.Equal(
+		t,
+		[]string{"Version v2 forks the history at version v1."},
+		history.GetViolations(),
+	)
+
+	// Gaps in the version sequence and missing versions are reported.
+	var content = v3.GetContent()
+	var gap = not.Document(not.Content(
+		doc.Moment(),
+		content.GetType(),
+		content.GetTag(),
+		doc.Version("v5"),
+		content.GetPermissions(),
+		notary.CiteDocument(v3).AsResource(),
+	))
+	notary.NotarizeDocument(gap)
+	repository.documents_ = []not.DocumentLike{v3, v2}
+	history = not.History(notary, repository, gap)
+	ass.Equal(
+		t,
+		[]string{
+			"There is a gap between version v3 and version v5.",
+			"The previous version v1 of version v2 could not be found.",
+		},
+		history.GetViolations(),
+	)
+	ass.Equal(t, []not.DocumentLike{v2, v3, gap}, history.GetVersions())
+	notary.ForgetKey()
+}
+
+func TestNotarizeNextVersion(t *tes.T) {
+	// Notarize the first version of a custom document.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificate = notary.GenerateKey(attributes)
+	var document = not.Document(not.Content(
+		doc.ParseComponent(`[ $status: $Draft ]`),
+		doc.Name("/bali/examples/Report/v1"),
+		doc.Tag(),
+		doc.Version("v1.2"),
+		doc.Name("/bali/permissions/Private/v3"),
+		nil,
+	))
+	notary.NotarizeDocument(document)
+
+	// Each level bumps the corresponding part of the version.
+	var entity = doc.ParseComponent(`[ $status: $Final ]`)
+	var versions = map[int]string{
+		not.LastLevel:  "v1.3",
+		not.MajorLevel: "v2",
+		not.MinorLevel: "v1.3",
+		not.PatchLevel: "v1.2.1",
+	}
+	for level, expected := range versions {
+		var next = notary.NotarizeNextVersion(document, entity, level)
+		var content = next.GetContent()
+		ass.Equal(t, expected, content.GetVersion().AsSource())
+		ass.Equal(t, document.GetContent().GetType(), content.GetType())
+		ass.Equal(t, document.GetContent().GetTag(), content.GetTag())
+		ass.Equal(t, "/bali/permissions/Private/v3", content.GetPermissions().AsSource())
+		var citation = not.Citation(content.GetOptionalPrevious())
+		ass.True(t, notary.CitationMatches(citation, document))
+		ass.True(t, notary.SealMatches(next, certificate))
+	}
+	ass.Panics(t, func() { notary.NotarizeNextVersion(document, entity, -1) })
+	notary.ForgetKey()
+}
+
+func TestEnvelopes(t *tes.T) {
+	// Create a sender and two recipients that publish their agreement keys.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var sender = notary.GenerateKey(attributes)
+	var recipients []not.DocumentLike
+	var notaries []not.DigitalNotaryLike
+	for _, name := range []string{"alice", "bob"} {
+		var module = HsmEd25519TestClass().HsmEd25519(name, secret)
+		var recipient = not.DigitalNotary(ssm, module)
+		var certificate = recipient.GenerateKey(attributes)
+		var agreementKey = module.(not.Agreeing).GetAgreementKey()
+		var published = not.Identity(certificate.GetContent())
+		ass.Equal(t, agreementKey, published.GetOptionalPublicKey("$agreementKey").AsIntrinsic())
+		recipients = append(recipients, certificate)
+		notaries = append(notaries, recipient)
+	}
+
+	// Only notarized documents may be encrypted.
+	var document = notary.GenerateCredential(doc.Moment())
+	var unsealed = not.Document(document.GetContent())
+	ass.Panics(t, func() { notary.EncryptDocument(unsealed, recipients) })
+
+	// Each recipient can decrypt and verify the document.
+	var envelope = notary.EncryptDocument(document, recipients)
+	envelope = not.Envelope(envelope.AsSource())
+	ass.Equal(t, 2, len(envelope.GetRecipients()))
+	ass.False(t, sts.Contains(envelope.AsSource(), document.GetContent().GetTag().AsSource()))
+	for _, recipient := range notaries {
+		var decrypted = recipient.DecryptEnvelope(envelope, sender)
+		ass.Equal(t, document.AsSource(), decrypted.AsSource())
+	}
+
+	// The seal is verified against the sender certificate.
+	ass.Panics(t, func() { notaries[0].DecryptEnvelope(envelope, recipients[1]) })
+
+	// The sender is not a recipient.
+	ass.Panics(t, func() { notary.DecryptEnvelope(envelope, sender) })
+
+	// Tampered envelopes cannot be decrypted.
+	var ciphertext = envelope.GetCiphertext().AsIntrinsic()
+	ciphertext[len(ciphertext)-1] ^= 1
+	var tampered = not.Envelope(
+		envelope.GetAlgorithm(),
+		envelope.GetEphemeralKey(),
+		doc.Binary(ciphertext),
+	)
+	for _, recipient := range envelope.GetRecipients() {
+		tampered.AddRecipient(recipient, envelope.GetWrappedKey(recipient))
+	}
+	ass.Panics(t, func() { notaries[1].DecryptEnvelope(tampered, sender) })
+	for _, recipient := range notaries {
+		recipient.ForgetKey()
+	}
+	notary.ForgetKey()
+}
+
+func TestAgreementKeys(t *tes.T) {
+	// The agreement key is published alongside the signing key.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificateV1 = notary.GenerateKey(attributes)
+	var identityV1 = not.Identity(certificateV1.GetContent())
+	ass.Equal(t, `"X25519"`, identityV1.GetOptionalKeyAlgorithm("$agreementKey").AsSource())
+	ass.Equal(t, hsm.(not.Agreeing).GetAgreementKey(), identityV1.GetOptionalPublicKey("$agreementKey").AsIntrinsic())
+	ass.Nil(t, identityV1.GetOptionalPublicKey("$missingKey"))
+
+	// Refreshing the signing key also rotates the agreement key.
+	var certificateV2 = notary.RefreshKey()
+	var identityV2 = not.Identity(certificateV2.GetContent())
+	var agreementKey = identityV2.GetOptionalPublicKey("$agreementKey").AsIntrinsic()
+	ass.Equal(t, hsm.(not.Agreeing).GetAgreementKey(), agreementKey)
+	ass.NotEqual(t, identityV1.GetOptionalPublicKey("$agreementKey").AsIntrinsic(), agreementKey)
+	ass.True(t, notary.SealMatches(certificateV2, certificateV1))
+
+	// Documents can be encrypted to the refreshed certificate.
+	var document = notary.GenerateCredential(doc.Moment())
+	var envelope = notary.EncryptDocument(document, []not.DocumentLike{certificateV2})
+	var decrypted = notary.DecryptEnvelope(envelope, certificateV2)
+	ass.Equal(t, document.AsSource(), decrypted.AsSource())
+
+	// Malformed additional keys are rejected.
+	var source = sts.Replace(certificateV2.AsSource(), `"X25519"`, "$X25519", 1)
+	var violations = not.DocumentClass().Violations(doc.ParseComponent(source))
+	ass.Equal(
+		t,
+		[]string{"$content: $agreementKey: The $algorithm attribute must be a quote value."},
+		violations,
+	)
+	notary.ForgetKey()
+}
+
+func TestPermissions(t *tes.T) {
+	// Create an owner and a reader with published certificates.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var owner = notary.GenerateKey(attributes)
+	var module = HsmEd25519TestClass().HsmEd25519("alice", secret)
+	var alice = not.DigitalNotary(ssm, module)
+	var reader = alice.GenerateKey(attributes)
+	var repository = &repository_{
+		documents_: []not.DocumentLike{owner, reader},
+	}
+	var trusted = []not.DocumentLike{owner, reader}
+	var guard = not.Guard(notary, repository, trusted)
+
+	// The owner grants the reader read access to team documents.
+	var name = doc.Name("/bali/permissions/Team/v3")
+	var permissions = not.Permissions(name, doc.Tag(), doc.Version(), nil)
+	permissions.GrantAccess("$read", reader.GetContent().GetTag())
+	ass.Panics(t, func() { permissions.GrantAccess("$delete", reader.GetContent().GetTag()) })
+	var team = not.Document(permissions)
+	notary.NotarizeDocument(team)
+	ass.Panics(t, func() { guard.AddPermissions(team) })
+	ass.Panics(t, func() { guard.SetOwner(name, doc.Tag()) })
+	guard.SetOwner(name, owner.GetContent().GetTag())
+	ass.Panics(t, func() { guard.SetOwner(name, reader.GetContent().GetTag()) })
+	ass.Equal(t, owner.GetContent().GetTag(), guard.GetOptionalOwner(name))
+
+	// Only the owner may define the first version of the permissions.
+	var squatter = not.Document(permissions)
+	alice.NotarizeDocument(squatter)
+	ass.Panics(t, func() { guard.AddPermissions(squatter) })
+	guard.AddPermissions(team)
+	permissions = guard.GetOptionalPermissions(name)
+	ass.Equal(t, []doc.TagLike{reader.GetContent().GetTag()}, permissions.GetGrants("$read"))
+	ass.Nil(t, guard.GetOptionalPermissions(doc.Name("/bali/permissions/Other/v3")))
+
+	// Access is evaluated against the permissions of each document.
+	var document = func(permissions string) not.DocumentLike {
+		var document = not.Document(not.Content(
+			doc.ParseComponent(`[ $status: $Draft ]`),
+			doc.Name("/bali/examples/Report/v1"),
+			doc.Tag(),
+			doc.Version(),
+			doc.Name(permissions),
+			nil,
+		))
+		notary.NotarizeDocument(document)
+		return document
+	}
+	var ownerCredential = notary.GenerateCredential(doc.Moment())
+	var readerCredential = alice.GenerateCredential(doc.Moment())
+	var cases = []struct {
+		permissions string
+		action      string
+		owner       bool
+		reader      bool
+	}{
+		{"/bali/permissions/Public/v3", "$read", true, true},
+		{"/bali/permissions/Public/v3", "$write", true, false},
+		{"/bali/permissions/Private/v3", "$read", true, false},
+		{"/bali/permissions/Team/v3", "$read", true, true},
+		{"/bali/permissions/Team/v3", "$write", true, false},
+		{"/bali/permissions/Team/v3", "$notarize", true, false},
+		{"/bali/permissions/Other/v3", "$read", true, false},
+	}
+	for _, c := range cases {
+		var document = document(c.permissions)
+		ass.Equal(t, c.owner, guard.CheckAccess(ownerCredential, document, c.action))
+		ass.Equal(t, c.reader, guard.CheckAccess(readerCredential, document, c.action))
+	}
+	ass.Panics(t, func() { guard.CheckAccess(ownerCredential, team, "$delete") })
+
+	// Credentials from unknown certificates and other documents are rejected.
+	repository.documents_ = []not.DocumentLike{owner}
+	ass.False(t, guard.CheckAccess(readerCredential, team, "$read"))
+	repository.documents_ = []not.DocumentLike{owner, reader}
+	ass.False(t, guard.CheckAccess(team, team, "$read"))
+
+	// A certificate that reuses the tag of a trusted certificate is rejected.
+	var mallory = HsmEd25519TestClass().HsmEd25519("mallory", secret)
+	var impostor = not.DigitalNotary(ssm, mallory)
+	var source = impostor.GenerateKey(attributes).AsSource()
+	var forged = not.Document(sts.ReplaceAll(
+		source,
+		not.Document(source).GetContent().GetTag().AsSource(),
+		reader.GetContent().GetTag().AsSource(),
+	))
+	var seal = forged.RemoveNotarySeal()
+	forged.SetNotarySeal(not.Seal(
+		seal.GetAlgorithm(),
+		doc.Binary(mallory.SignBytes(forged.AsCanonical())),
+		seal.GetOptionalFormat(),
+	))
+	repository.documents_ = []not.DocumentLike{owner, forged}
+	impostor = not.DigitalNotary(ssm, mallory, forged, repository)
+	var forgedCredential = impostor.GenerateCredential(doc.Moment())
+	ass.Equal(t, reader.GetContent().GetTag(), forgedCredential.GetNotaryCitation().GetTag())
+	ass.False(t, guard.CheckAccess(forgedCredential, document("/bali/permissions/Team/v3"), "$read"))
+	var tampered = not.Document(sts.Replace(source, `"Norton"`, `"Mallory"`, 1))
+	ass.Panics(t, func() { not.Guard(notary, repository, []not.DocumentLike{tampered}) })
+	repository.documents_ = []not.DocumentLike{owner, reader}
+	impostor.ForgetKey()
+
+	// Only the owner may replace the permissions with a later version.
+	permissions = not.Permissions(
+		name,
+		permissions.GetTag(),
+		doc.Version("v2"),
+		notary.CiteDocument(team).AsResource(),
+	)
+	permissions.GrantAccess("$write", reader.GetContent().GetTag())
+	var update = not.Document(permissions)
+	alice.NotarizeDocument(update)
+	ass.Panics(t, func() { guard.AddPermissions(update) })
+	update = not.Document(permissions)
+	notary.NotarizeDocument(update)
+	guard.AddPermissions(update)
+	ass.True(t, guard.CheckAccess(readerCredential, document("/bali/permissions/Team/v3"), "$write"))
+	ass.Panics(t, func() { guard.AddPermissions(team) })
+	alice.ForgetKey()
+	notary.ForgetKey()
+}
+
+func TestPolicies(t *tes.T) {
+	// Notarize an invoice with the first and second versions of a certificate.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificateV1 = notary.GenerateKey(attributes)
+	var invoice = func() not.DocumentLike {
+		var document = not.Document(not.Content(
+			doc.ParseComponent(`[ $amount: 42 ]`),
+			doc.Name("/acme/Invoice/v1"),
+			doc.Tag(),
+			doc.Version(),
+			doc.Name("/bali/permissions/Public/v3"),
+			nil,
+		))
+		notary.NotarizeDocument(document)
+		return document
+	}
+	var early = invoice()
+	var certificateV2 = notary.RefreshKey()
+	var current = invoice()
+	var certificates = []not.DocumentLike{certificateV1, certificateV2}
+
+	// Define a policy that requires recent seals by the second certificate.
+	var policy = not.Policy(doc.Tag(), doc.Version(), nil)
+	policy.AddRule(
+		doc.Name("/acme/Invoice/v1"),
+		[]doc.TagLike{certificateV2.GetContent().GetTag()},
+		doc.Duration("~P1D"),
+		doc.Version("v2"),
+	)
+	ass.Panics(t, func() {
+		policy.AddRule(doc.Name("/acme/Invoice/v1"), nil, nil, nil)
+	})
+	policy = not.Policy(policy.AsSource())
+	ass.Equal(t, "~P1D", policy.GetOptionalMaximumAge(doc.Name("/acme/Invoice/v1")).AsSource())
+	ass.Nil(t, policy.GetOptionalMinimumVersion(doc.Name("/acme/Receipt/v1")))
+
+	// A matching document is allowed.
+	var decision = not.Decision(notary, policy, current, certificates, doc.Moment())
+	ass.True(t, decision.IsAllowed())
+	ass.Equal(t, 0, len(decision.GetReasons()))
+
+	// Each violated restriction is reported as a reason.
+	decision = not.Decision(notary, policy, early, certificates, doc.Moment())
+	ass.False(t, decision.IsAllowed())
+	ass.Equal(t, []string{"The certificate version v1 is earlier than the minimum version v2."}, decision.GetReasons())
+	decision = not.Decision(notary, policy, current, []not.DocumentLike{certificateV1}, doc.Moment())
+	ass.Equal(t, 1, len(decision.GetReasons()))
+	ass.True(t, sts.Contains(decision.GetReasons()[0], "was not provided"))
+	var tampered = not.Document(sts.Replace(current.AsSource(), "42", "24", 1))
+	decision = not.Decision(notary, policy, tampered, certificates, doc.Moment())
+	ass.Equal(t, []string{"The seal on the document does not match its certificate."}, decision.GetReasons())
+	decision = not.Decision(notary, policy, current, []not.DocumentLike{certificateV2}, doc.Moment())
+	ass.Equal(t, []string{"The previous version of certificate version v2 was not provided."}, decision.GetReasons())
+	var forged = not.Document(sts.Replace(certificateV1.AsSource(), `"Norton"`, `"Mallory"`, 1))
+	decision = not.Decision(notary, policy, current, []not.DocumentLike{forged, certificateV2}, doc.Moment())
+	ass.Equal(t, []string{"The previous version of certificate version v2 was not provided."}, decision.GetReasons())
+	decision = not.Decision(notary, policy, certificateV2, certificates, doc.Moment())
+	ass.Equal(t, []string{"The policy has no rule for documents of type /bali/types/notary/Identity/v3."}, decision.GetReasons())
+
+	// Seals from other notaries or older than the maximum age are denied.
+	var strict = not.Policy(doc.Tag(), doc.Version(), nil)
+	strict.AddRule(doc.Name("/acme/Invoice/v1"), []doc.TagLike{doc.Tag()}, doc.Duration("~PT0S"), nil)
+	time.Sleep(5 * time.Millisecond)
+	decision = not.Decision(notary, strict, current, certificates, doc.Moment())
+	var reasons = decision.GetReasons()
+	ass.Equal(t, 2, len(reasons))
+	ass.True(t, sts.Contains(reasons[0], "is not one of the notaries accepted by the policy"))
+	ass.True(t, sts.Contains(reasons[1], "is older than the maximum age"))
+
+	// The maximum age is evaluated at the moment of the decision.
+	var moment = doc.MomentClass().Earlier(doc.Moment(), doc.Duration("~PT1H"))
+	decision = not.Decision(notary, policy, current, certificates, moment)
+	ass.Equal(t, moment, decision.GetMoment())
+	reasons = decision.GetReasons()
+	ass.Equal(t, 1, len(reasons))
+	ass.True(t, sts.Contains(reasons[0], "is later than the moment of the decision"))
+	moment = doc.MomentClass().Later(doc.Moment(), doc.Duration("~P2D"))
+	decision = not.Decision(notary, policy, current, certificates, moment)
+	reasons = decision.GetReasons()
+	ass.Equal(t, 1, len(reasons))
+	ass.True(t, sts.Contains(reasons[0], "is older than the maximum age"))
+
+	// Policies may themselves be notarized and are validated as such.
+	var document = not.Document(policy)
+	notary.NotarizeDocument(document)
+	policy = not.Policy(document.GetContent())
+	ass.Equal(t, 1, len(policy.GetTypes()))
+	var source = sts.Replace(document.AsSource(), "~P1D", "24", 1)
+	var violations = not.DocumentClass().Violations(doc.ParseComponent(source))
+	ass.Equal(
+		t,
+		[]string{"$content: $rules[1]: The $maximumAge attribute must be a duration value."},
+		violations,
+	)
+	notary.ForgetKey()
+}
+
+func TestAuditLog(t *tes.T) {
+	// Record the operations of the digital notary in a new audit log.
+	notary.ForgetKey()
+	var directory = testDirectory + "audit"
+	uti.RemovePath(directory)
+	var audit = not.AuditFile(notary, directory)
+	notary.SetAuditSink(audit)
+	var attributes = identity.GetAttributes()
+	var certificate = notary.GenerateKey(attributes)
+	var credential = notary.GenerateCredential(doc.Moment())
+	var certificateV2 = notary.RefreshKey()
+	ass.True(t, notary.SealMatches(credential, certificate))
+	notary.ForgetKey()
+	notary.SetAuditSink(nil)
+	var trusted = []not.DocumentLike{certificate}
+	var certificates = &repository_{
+		documents_: []not.DocumentLike{certificate, certificateV2},
+	}
+
+	// Each entry records the operation and cites its subject.
+	var entries = audit.GetEntries()
+	var operations = []string{"GenerateKey", "GenerateCredential", "RefreshKey", "ForgetKey"}
+	ass.Equal(t, len(operations), len(entries))
+	for index, operation := range operations {
+		var content = entries[index].GetContent()
+		ass.True(t, sts.Contains(content.AsSource(), `"`+operation+`"`))
+		ass.Equal(t, fmt.Sprintf("v%d", index+1), content.GetVersion().AsSource())
+	}
+	var subject = entries[1].GetContent().AsSource()
+	ass.True(t, sts.Contains(subject, notary.CiteDocument(credential).AsResource().AsSource()))
+	ass.Equal(t, 0, len(audit.VerifyEntries(trusted, certificates)))
+
+	// The log is read back in and forms a valid history.
+	audit = not.AuditFile(notary, directory)
+	ass.Equal(t, len(entries), len(audit.GetEntries()))
+	ass.Equal(t, entries[3].AsSource(), audit.GetEntries()[3].AsSource())
+	var repository = &repository_{documents_: audit.GetEntries()}
+	var history = not.History(notary, repository, audit.GetEntries()[3])
+	ass.True(t, history.IsValid())
+
+	// Any change to an entry is detected by the entry that follows it.
+	var filename = directory + "/AuditLog.bali"
+	var source = uti.ReadFile(filename)
+	source = sts.Replace(source, `"GenerateCredential"`, `"NotarizeDocument"`, 1)
+	uti.WriteFile(filename, source)
+	audit = not.AuditFile(notary, directory)
+	ass.Equal(
+		t,
+		[]string{
+			"The seal on entry v2 does not match its certificate.",
+			"Entry v3 does not cite the digest of entry v2.",
+		},
+		audit.VerifyEntries(trusted, certificates),
+	)
+
+	// The entries must be sealed by a trusted certificate.
+	uti.WriteFile(filename, sts.Replace(source, `"NotarizeDocument"`, `"GenerateCredential"`, 1))
+	audit = not.AuditFile(notary, directory)
+	var eve = HsmEd25519TestClass().HsmEd25519("eve", secret)
+	var stranger = not.DigitalNotary(ssm, eve)
+	var foreign = stranger.GenerateKey(attributes)
+	stranger.ForgetKey()
+	ass.Equal(
+		t,
+		[]string{
+			"The certificate for entry v1 is not trusted.",
+			"The certificate for entry v2 is not trusted.",
+			"The certificate for entry v3 is not trusted.",
+			"The certificate for entry v4 is not trusted.",
+		},
+		audit.VerifyEntries([]not.DocumentLike{foreign}, certificates),
+	)
+
+	// An operation cannot change the structure of its entry.
+	uti.RemovePath(directory)
+	certificate = notary.GenerateKey(attributes)
+	audit = not.AuditFile(notary, directory)
+	var operation = "Hostile\"\n\n$admin: true\n\n"
+	audit.RecordEvent(operation, nil)
+	notary.ForgetKey()
+	audit = not.AuditFile(notary, directory)
+	ass.Equal(t, 1, len(audit.GetEntries()))
+	var component = audit.GetEntries()[0].GetSubcomponent(
+		doc.Symbol("$content"),
+		doc.Symbol("$operation"),
+	)
+	ass.Equal(
+		t,
+		doc.QuoteClass().Quote([]rune(operation)).AsSource(),
+		doc.FormatComponent(component),
+	)
+	ass.Equal(
+		t,
+		0,
+		len(audit.VerifyEntries([]not.DocumentLike{certificate}, &repository_{
+			documents_: []not.DocumentLike{certificate},
+		})),
+	)
+}
+
+func TestTransparencyLog(t *tes.T) {
+	// Log each certificate and document notarized by the digital notary.
+	notary.ForgetKey()
+	var directory = testDirectory + "transparency"
+	uti.RemovePath(directory)
+	var ssm = not.SsmSha512()
+	var log = not.TransparencyLog(notary, ssm, directory)
+	notary.SetTransparencyLog(log)
+	var attributes = identity.GetAttributes()
+	var certificate = notary.GenerateKey(attributes)
+	ass.Equal(t, uint(1), log.GetSize())
+	var firstHead = log.GetTreeHead()
+	ass.True(t, notary.ProofMatches(certificate, firstHead))
+
+	// The inclusion proof does not invalidate the seal on the document.
+	var content = not.Content(
+		doc.Quote(`"Hello World!"`),
+		doc.Name("/bali/types/documents/Message/v3"),
+		doc.Tag(),
+		doc.Version(),
+		doc.Name("/bali/permissions/Public/v3"),
+		nil,
+	)
+	var document = not.Document(content)
+	notary.NotarizeDocument(document)
+	ass.Equal(t, uint(2), log.GetSize())
+	ass.True(t, notary.SealMatches(document, certificate))
+	ass.True(t, notary.ProofMatches(document, log.GetTreeHead()))
+	ass.False(t, notary.ProofMatches(document, firstHead))
+	certificate = notary.RefreshKey()
+	ass.Equal(t, uint(3), log.GetSize())
+	var lastHead = log.GetTreeHead()
+	ass.True(t, notary.ProofMatches(certificate, lastHead))
+
+	// An earlier document can be proven against the latest tree head.
+	document.RemoveNotaryProof()
+	var citation = notary.CiteDocument(document)
+	document.SetNotaryProof(log.ProveInclusion(citation))
+	ass.True(t, notary.ProofMatches(document, lastHead))
+
+	// The latest tree head is consistent with the first one.
+	var tree = not.MerkleTree(ssm)
+	var proof = log.ProveConsistency(1, 3)
+	var path [][]byte
+	for _, hash := range proof.GetPath() {
+		path = append(path, hash.AsIntrinsic())
+	}
+	var firstRoot = not.TreeHead(firstHead.GetContent()).GetRoot().AsIntrinsic()
+	var lastRoot = not.TreeHead(lastHead.GetContent()).GetRoot().AsIntrinsic()
+	ass.True(t, tree.VerifyConsistency(1, 3, firstRoot, lastRoot, path))
+	ass.False(t, tree.VerifyConsistency(1, 3, lastRoot, firstRoot, path))
+
+	// The log is read back in from the filesystem.
+	log = not.TransparencyLog(notary, ssm, directory)
+	ass.Equal(t, uint(3), log.GetSize())
+	ass.Equal(t, lastHead.AsSource(), log.GetTreeHead().AsSource())
+	notary.SetTransparencyLog(nil)
+	notary.ForgetKey()
+
+	// A log whose citations no longer match its signed tree head is refused.
+	var filename = directory + "/Citations.log"
+	var source = uti.ReadFile(filename)
+	var lines = sts.Split(source, "\n")
+	lines[0], lines[1] = lines[1], lines[0]
+	uti.WriteFile(filename, sts.Join(lines, "\n"))
+	defer func() {
+		if e := recover(); e != nil {
+			ass.True(t, sts.Contains(fmt.Sprint(e), "not consistent"))
+		} else {
+			ass.Fail(t, "Test should result in recovered panic.")
+		}
+	}()
+	not.TransparencyLog(notary, ssm, directory)
+}
+
+func TestMerkleTree(t *tes.T) {
+	// Every leaf is included in and every tree is consistent with each larger
+	// tree.
+	var tree = not.MerkleTree(ssm)
+	var roots [][]byte
+	for size := uint(1); size <= 13; size++ {
+		tree.AddLeaf([]byte(fmt.Sprintf("leaf %d", size-1)))
+		roots = append(roots, tree.GetRoot(size))
+	}
+	for size := uint(1); size <= 13; size++ {
+		var root = tree.GetRoot(size)
+		ass.Equal(t, roots[size-1], root)
+		for index := uint(0); index < size; index++ {
+			var leaf = []byte(fmt.Sprintf("leaf %d", index))
+			var path = tree.ProveInclusion(index, size)
+			ass.True(t, tree.VerifyInclusion(leaf, index, size, path, root))
+		}
+		for first := uint(1); first <= size; first++ {
+			var path = tree.ProveConsistency(first, size)
+			ass.True(t, tree.VerifyConsistency(first, size, roots[first-1], root, path))
+		}
+	}
+}
+
+func TestNotaryService(t *tes.T) {
+	// Start a notary service with a client notary that it trusts.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificate = notary.GenerateKey(attributes)
+	var local = not.DigitalNotary(ssm, HsmEd25519TestClass().HsmEd25519("client", secret))
+	var localCertificate = local.GenerateKey(attributes)
+	var repository = &repository_{documents_: []not.DocumentLike{localCertificate}}
+	var service = htt.NewServer(ser.ServerClass().Server(notary, repository))
+	defer service.Close()
+	var client = ser.ClientClass().Client(service.URL, local)
+
+	// The public endpoints do not require a credential.
+	ass.Equal(t, certificate.AsSource(), client.GetCertificate().AsSource())
+	var citation = client.CiteDocument(certificate)
+	ass.Equal(t, notary.CiteDocument(certificate).AsSource(), citation.AsSource())
+	ass.True(t, client.SealMatches(certificate, certificate))
+	ass.False(t, client.SealMatches(localCertificate, certificate))
+
+	// The notarized document is sealed by the notary of the service.
+	var content = not.Content(
+		doc.Quote(`"Hello World!"`),
+		doc.Name("/bali/types/documents/Message/v3"),
+		doc.Tag(),
+		doc.Version(),
+		doc.Name("/bali/permissions/Public/v3"),
+		nil,
+	)
+	var document = not.Document(content)
+	client.NotarizeDocument(document)
+	ass.True(t, notary.SealMatches(document, certificate))
+	var credential = client.GenerateCredential(doc.Moment())
+	ass.True(t, notary.SealMatches(credential, certificate))
+
+	// A client that is unknown to the service is not authenticated.
+	var anonymous = ser.ClientClass().Client(service.URL, nil)
+	ass.Panics(t, func() { anonymous.NotarizeDocument(not.Document(content)) })
+	var stranger = not.DigitalNotary(ssm, HsmEd25519TestClass().HsmEd25519("stranger", secret))
+	stranger.GenerateKey(attributes)
+	var other = ser.ClientClass().Client(service.URL, stranger)
+	var generate = func() { other.GenerateCredential(doc.Moment()) }
+	ass.PanicsWithValue(
+		t,
+		"Client: An error occurred while attempting to generate a security credential:\n"+
+			"    The service returned 401 Unauthorized: "+
+			"A valid credential is required for this request.",
+		generate,
+	)
+
+	// A credential is bound to the body of its request and may only be used
+	// once.
+	var send = func(body string, credential not.DocumentLike) int {
+		var request, _ = hts.NewRequest(hts.MethodPost, service.URL+"/notarize", sts.NewReader(body))
+		var encoded = b64.StdEncoding.EncodeToString([]byte(credential.AsSource()))
+		request.Header.Set("Authorization", ser.ServerClass().Scheme()+" "+encoded)
+		var response, err = hts.DefaultClient.Do(request)
+		ass.Nil(t, err)
+		response.Body.Close()
+		return response.StatusCode
+	}
+	var body = not.Document(content).AsSource()
+	var digest = sha.Sum512([]byte(body))
+	var context = doc.ParseComponent(`[
+    $request: "POST /notarize"
+    $digest: none
+]`)
+	context.SetSubcomponent(doc.Binary(digest[:]), doc.Symbol("$digest"))
+	var single = local.GenerateCredential(context)
+	ass.Equal(t, hts.StatusUnauthorized, send(sts.Replace(body, "Hello", "Goodbye", 1), single))
+	ass.Equal(t, hts.StatusOK, send(body, single))
+	ass.Equal(t, hts.StatusUnauthorized, send(body, single))
+
+	// The certificate of the service itself is never accepted.
+	repository.documents_ = append(repository.documents_, certificate)
+	ass.Equal(t, hts.StatusUnauthorized, send(body, notary.GenerateCredential(context)))
+
+	// The details of an invalid request are not revealed.
+	var response, err = hts.Post(service.URL+"/cite", ser.ServerClass().MediaType(), sts.NewReader("["))
+	ass.Nil(t, err)
+	var bytes, _ = iox.ReadAll(response.Body)
+	response.Body.Close()
+	ass.Equal(t, hts.StatusBadRequest, response.StatusCode)
+	ass.Equal(t, "The request could not be processed.\n", string(bytes))
+	local.ForgetKey()
+	stranger.ForgetKey()
+	notary.ForgetKey()
+}
+
+func TestJSON(t *tes.T) {
+	// A notarized certificate survives the conversion to JSON and back.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificate = notary.GenerateKey(attributes)
+	var source = certificate.AsJSON()
+	ass.True(t, jsn.Valid([]byte(source)))
+	ass.True(t, sts.Contains(source, `"@parameters": {`))
+	ass.True(t, sts.Contains(source, `"$type": "/bali/types/notary/Identity/v3"`))
+	var document = not.DocumentClass().DocumentFromJSON(source)
+	ass.Equal(t, certificate.AsSource(), document.AsSource())
+	ass.Equal(t, source, document.AsJSON())
+	ass.True(t, notary.SealMatches(document, certificate))
+	var citation = notary.CiteDocument(certificate)
+	ass.Equal(t, citation.AsSource(), notary.CiteDocument(document).AsSource())
+	ass.True(t, notary.CitationMatches(citation, document))
+	var copy = not.CitationClass().CitationFromJSON(citation.AsJSON())
+	ass.Equal(t, citation.AsSource(), copy.AsSource())
+
+	// Empty collections and binary values are preserved.
+	var component = not.JSONClass().ParseJSON(`{
+    "$empty": {},
+    "$items": [],
+    "$flag": true,
+    "$bytes": "'AAECAw=='"
+}`)
+	ass.Equal(t, []byte{0, 1, 2, 3}, component.GetSubcomponent(doc.Symbol("$bytes")).GetLiteral().(doc.BinaryLike).AsIntrinsic())
+	ass.Equal(t, "[:]", doc.FormatComponent(component.GetSubcomponent(doc.Symbol("$empty"))))
+	ass.Panics(t, func() { not.JSONClass().ParseJSON(`{"$a": null}`) })
+	ass.Panics(t, func() { not.JSONClass().ParseJSON(`{} {}`) })
+
+	// A string cannot change the structure of the component.
+	ass.PanicsWithValue(
+		t,
+		`A JSON string must contain a single primitive value: "\"Bob\"\n$admin: true"`,
+		func() { not.JSONClass().ParseJSON(`{"$name": "\"Bob\"\n$admin: true"}`) },
+	)
+	ass.Panics(t, func() { not.JSONClass().ParseJSON(`{"$name": "[$admin: true]"}`) })
+	ass.Panics(t, func() { not.JSONClass().ParseJSON(`{"$a: 1\n$admin": true}`) })
+	ass.Panics(t, func() { not.JSONClass().ParseJSON(`{"$name": "\"Bob\"($type: /bali/types/Admin/v3)"}`) })
+
+	// The notary service accepts and returns JSON.
+	var repository = &repository_{}
+	var service = htt.NewServer(ser.ServerClass().Server(notary, repository))
+	defer service.Close()
+	var request, _ = hts.NewRequest(
+		hts.MethodPost,
+		service.URL+"/cite",
+		sts.NewReader(source),
+	)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	var response, err = hts.DefaultClient.Do(request)
+	ass.Nil(t, err)
+	defer response.Body.Close()
+	var bytes, _ = iox.ReadAll(response.Body)
+	ass.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	ass.Equal(t, citation.AsJSON(), string(bytes))
+	notary.ForgetKey()
+}
+
+func coseString(
+	value []byte,
+) []byte {
+	// Only the byte string lengths used by the test are supported.
+	var length = len(value)
+	switch {
+	case length < 24:
+		return append([]byte{0x40 | byte(length)}, value...)
+	case length < 256:
+		return append([]byte{0x58, byte(length)}, value...)
+	default:
+		return append([]byte{0x59, byte(length >> 8), byte(length)}, value...)
+	}
+}
+
+func TestJWSAndCOSE(t *tes.T) {
+	// Export a notarized document as a compact JWS.
+	notary.ForgetKey()
+	var attributes = identity.GetAttributes()
+	var certificate = notary.GenerateKey(attributes)
+	var key = not.Identity(certificate.GetContent()).GetKey().AsIntrinsic()
+	var document = notary.GenerateCredential(doc.Moment())
+	var jws = notary.ExportJWS(document)
+	var parts = sts.Split(jws, ".")
+	ass.Equal(t, 3, len(parts))
+	var header, _ = b64.RawURLEncoding.DecodeString(parts[0])
+	var resource = notary.CiteDocument(certificate).AsResource().AsSource()
+	ass.True(t, sts.Contains(string(header), `"kid":"`+resource[1:len(resource)-1]+`"`))
+	var signature, _ = b64.RawURLEncoding.DecodeString(parts[2])
+	ass.True(t, sig.Verify(key, []byte(parts[0]+"."+parts[1]), signature))
+
+	// The imported JWS is a document that matches its seal.
+	var imported = not.DocumentClass().DocumentFromJWS(jws)
+	ass.True(t, notary.SealMatches(imported, certificate))
+	ass.Equal(t, jws, imported.AsJWS())
+	var payload = not.Payload(imported.GetContent())
+	ass.Equal(t, document.AsSource(), string(payload.GetPayload().AsIntrinsic()))
+	ass.Equal(t, notary.CiteDocument(certificate).AsSource(), imported.GetNotaryCitation().AsSource())
+	var tampered = parts[0] + "." + b64.RawURLEncoding.EncodeToString([]byte("tampered")) + "." + parts[2]
+	ass.False(t, notary.SealMatches(not.DocumentClass().DocumentFromJWS(tampered), certificate))
+	ass.Panics(t, func() { imported.AsCOSE() })
+
+	// The values that are not covered by the signature are derived from it.
+	ass.True(t, sts.Contains(string(header), `"iat":`))
+	ass.Equal(t, imported.AsSource(), not.DocumentClass().DocumentFromJWS(jws).AsSource())
+	var timestamp = not.Payload(imported.GetContent()).GetTimestamp().AsSource()
+	var changed = not.Document(sts.Replace(imported.AsSource(), timestamp, "<2000-01-01T00:00:00>", 1))
+	ass.False(t, notary.SealMatches(changed, certificate))
+	changed = not.Document(sts.Replace(imported.AsSource(), "$version: v1", "$version: v2", 1))
+	ass.False(t, notary.SealMatches(changed, certificate))
+	var tag = imported.GetContent().GetTag().AsSource()
+	changed = not.Document(sts.Replace(imported.AsSource(), tag, doc.Tag().AsSource(), 1))
+	ass.False(t, notary.SealMatches(changed, certificate))
+
+	// Export the same document as a COSE_Sign1 structure.
+	var cose = notary.ExportCOSE(document)
+	imported = not.DocumentClass().DocumentFromCOSE(cose)
+	ass.True(t, notary.SealMatches(imported, certificate))
+	ass.Equal(t, cose, imported.AsCOSE())
+	payload = not.Payload(imported.GetContent())
+	var protected = payload.GetProtected().AsIntrinsic()
+	var structure = append([]byte{0x84, 0x6a}, "Signature1"...)
+	structure = append(structure, coseString(protected)...)
+	structure = append(structure, coseString(nil)...)
+	structure = append(structure, coseString(payload.GetPayload().AsIntrinsic())...)
+	signature = cose[len(cose)-64:]
+	ass.True(t, sig.Verify(key, structure, signature))
+	ass.Equal(t, resource[1:len(resource)-1], payload.GetKeyID())
+	ass.Equal(t, 0, payload.GetTimestamp().AsIntrinsic()%1000)
+	timestamp = payload.GetTimestamp().AsSource()
+	changed = not.Document(sts.Replace(imported.AsSource(), timestamp, "<2000-01-01T00:00:00>", 1))
+	ass.False(t, notary.SealMatches(changed, certificate))
+	ass.Panics(t, func() { imported.AsJWS() })
+	ass.Panics(t, func() { not.DocumentClass().DocumentFromCOSE(cose[:len(cose)-1]) })
+
+	// The seal algorithm is taken from the protected header, and any other
+	// algorithm than EdDSA is rejected.
+	ass.Equal(t, "EdDSA", payload.GetAlgorithm())
+	ass.True(t, sts.Contains(imported.AsSource(), `$algorithm: "ED25519"`))
+	var unsupported = b64.RawURLEncoding.EncodeToString(
+		[]byte(sts.Replace(string(header), `"alg":"EdDSA"`, `"alg":"ES256"`, 1)),
+	)
+	func() {
+		defer func() {
+			var message = fmt.Sprint(recover())
+			ass.True(t, sts.Contains(message, "unsupported algorithm: ES256"), message)
+		}()
+		not.DocumentClass().DocumentFromJWS(unsupported + "." + parts[1] + "." + parts[2])
+	}()
+	func() {
+		defer func() {
+			var message = fmt.Sprint(recover())
+			ass.True(t, sts.Contains(message, "unsupported algorithm: -7"), message)
+		}()
+		not.DocumentClass().DocumentFromCOSE(
+			[]byte(sts.Replace(string(cose), "\xa4\x01\x27", "\xa4\x01\x26", 1)),
+		)
+	}()
+	notary.ForgetKey()
+}
+
+func TestVerifiableCredentials(t *tes.T) {
+	// Derive a DID and its DID document from a new certificate.
+	var certificate = notary.GenerateKey(identity.GetAttributes())
+	var class = not.VerifiableCredentialClass()
+	var did = class.DidKey(certificate)
+	ass.True(t, sts.HasPrefix(did, "did:key:z6Mk"))
+	var didDocument map[string]any
+	ass.Nil(t, jsn.Unmarshal([]byte(class.DidDocument(certificate)), &didDocument))
+	ass.Equal(t, did, didDocument["id"])
+	ass.Equal(t, []any{did + "#" + did[8:]}, didDocument["assertionMethod"])
+
+	// Issue a notarized credential as a verifiable credential.
+	var credential = notary.GenerateCredential(doc.Quote(`"GET /resource"`))
+	var exported = notary.ExportCredential(credential)
+	ass.True(t, class.ProofMatches(exported, did))
+	ass.True(t, sts.HasPrefix(exported, `{"@context":["https://www.w3.org/ns/credentials/v2"],"credentialSubject":{`))
+	var vc map[string]any
+	ass.Nil(t, jsn.Unmarshal([]byte(exported), &vc))
+	ass.Equal(t, did, vc["issuer"])
+	var resource = notary.CiteDocument(credential).AsResource().AsSource()
+	ass.Equal(t, resource[1:len(resource)-1], vc["id"])
+	var proof = vc["proof"].(map[string]any)
+	ass.Equal(t, "eddsa-jcs-2022", proof["cryptosuite"])
+	ass.Equal(t, did+"#"+did[8:], proof["verificationMethod"])
+
+	// Any change to the verifiable credential invalidates its proof.
+	var tampered = sts.Replace(exported, "GET /resource", "PUT /resource", 1)
+	ass.NotEqual(t, exported, tampered)
+	ass.False(t, class.ProofMatches(tampered, did))
+
+	// The proof must be made by the expected issuer.
+	var mallory = not.DigitalNotary(ssm, HsmEd25519TestClass().HsmEd25519("issuer", secret))
+	var other = mallory.GenerateKey(identity.GetAttributes())
+	var forged = mallory.ExportCredential(mallory.GenerateCredential(doc.Quote(`"GET /resource"`)))
+	ass.True(t, class.ProofMatches(forged, class.DidKey(other)))
+	ass.False(t, class.ProofMatches(forged, did))
+	mallory.ForgetKey()
+
+	// Only credentials notarized by the current certificate may be issued.
+	var copy = not.Document(sts.Replace(credential.AsSource(), "GET /resource", "PUT /resource", 1))
+	ass.Panics(t, func() { notary.ExportCredential(copy) })
+	notary.RefreshKey()
+	ass.Panics(t, func() { notary.ExportCredential(credential) })
+
+	// Only credentials may be issued as verifiable credentials.
+	var document = not.Document(not.Payload(
+		doc.Binary([]byte("{}")),
+		doc.Binary([]byte("{}")),
+		doc.Tag(),
+		doc.Version(),
+		nil,
+	))
+	notary.NotarizeDocument(document)
+	ass.Panics(t, func() { notary.ExportCredential(document) })
+	notary.ForgetKey()
+}
+
+func TestX509Certificates(t *tes.T) {
+	// Create a self-signed X.509 certificate for the notary identity.
+	var certificate = notary.GenerateKey(identity.GetAttributes())
+	var notaryIdentity = not.Identity(certificate.GetContent())
+	var arc = []uint{1, 3, 6, 1, 4, 1, 32473} // The documentation arc from RFC 5612.
+	var class = not.X509(arc)
+	ass.Equal(t, arc, class.GetArc())
+	ass.Panics(t, func() { not.X509([]uint{3, 1}) })
+	var bytes = class.CertificateFromIdentity(notaryIdentity, hsm, doc.Duration("~P1Y"))
+	var parsed, err = x50.ParseCertificate(bytes)
+	ass.Nil(t, err)
+	ass.Nil(t, parsed.CheckSignature(parsed.SignatureAlgorithm, parsed.RawTBSCertificate, parsed.Signature))
+	ass.Equal(t, notaryIdentity.GetTag().AsSource(), parsed.Subject.CommonName)
+	ass.Equal(t, sig.PublicKey(notaryIdentity.GetKey().AsIntrinsic()), parsed.PublicKey)
+
+	// The identity is recovered from the X.509 certificate.
+	var recovered = class.IdentityFromCertificate(bytes, nil)
+	ass.Equal(t, notaryIdentity.AsSource(), recovered.AsSource())
+	ass.True(t, parsed.Extensions[len(parsed.Extensions)-1].Id.Equal([]int{1, 3, 6, 1, 4, 1, 32473, 1, 3}))
+
+	// The certificate must be signed by its issuer and currently valid.
+	var other = not.X509([]uint{1, 3, 6, 1, 4, 1, 32473, 2})
+	ass.Panics(t, func() { other.IdentityFromCertificate(bytes, nil) })
+	var tampered = append([]byte{}, bytes...)
+	tampered[len(tampered)-1] ^= 0x01
+	ass.Panics(t, func() { class.IdentityFromCertificate(tampered, nil) })
+	var expired = class.CertificateFromIdentity(notaryIdentity, hsm, doc.Duration("~PT0S"))
+	time.Sleep(1100 * time.Millisecond)
+	ass.Panics(t, func() { class.IdentityFromCertificate(expired, nil) })
+	var signer = not.Signer(hsm)
+	ass.Equal(t, sig.PublicKey(notaryIdentity.GetKey().AsIntrinsic()), signer.Public())
+	var template = &x50.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x50.KeyUsageCertSign,
+	}
+	var public, private, _ = sig.GenerateKey(ran.Reader)
+	var issuer, _ = x50.CreateCertificate(ran.Reader, template, template, public, private)
+	ass.Panics(t, func() { class.IdentityFromCertificate(bytes, issuer) })
+
+	// Create a certificate signing request for a CA-signed certificate.
+	bytes = class.RequestFromIdentity(notaryIdentity, hsm)
+	request, err := x50.ParseCertificateRequest(bytes)
+	ass.Nil(t, err)
+	ass.Nil(t, request.CheckSignature())
+	ass.Equal(t, 3, len(request.Extensions))
+
+	// Only the hardened module that holds the key may sign for the identity.
+	ass.Panics(t, func() { class.RequestFromIdentity(identity, hsm) })
+	ass.Panics(t, func() { class.IdentityFromCertificate(bytes, nil) })
+	notary.ForgetKey()
+}
+
+func TestOpenPGPSignatures(t *tes.T) {
+	// Sign an artifact using each supported OpenPGP version.
+	notary.GenerateKey(identity.GetAttributes())
+	var class = not.OpenPGPClass()
+	var artifact = []byte("A release artifact.\n")
+	for _, version := range []uint{4, 6} {
+		var key = notary.ExportPGPKey(version)
+		var signature = notary.ExportPGPSignature(artifact, version)
+		ass.True(t, class.SignatureMatches(key, artifact, signature))
+		ass.False(t, class.SignatureMatches(key, []byte("A tampered artifact.\n"), signature))
+
+		// The public key packet is the same each time it is exported.
+		ass.Equal(t, key[:44], notary.ExportPGPKey(version)[:44])
+	}
+
+	// The signature must be made by the key of the same version.
+	var signature = notary.ExportPGPSignature(artifact, 4)
+	ass.False(t, class.SignatureMatches(notary.ExportPGPKey(6), artifact, signature))
+	ass.Panics(t, func() { notary.ExportPGPSignature(artifact, 5) })
+	notary.ForgetKey()
+}
+
+func TestOpenPGPVectors(t *tes.T) {
+	// These vectors were produced by independent implementations: the version 4
+	// key and signature by GnuPG 2.2 and the version 6 key and signature by the
+	// ProtonMail go-crypto library.
+	var class = not.OpenPGPClass()
+	var artifact = []byte("A release artifact.\n")
+	var vectors = [][]string{
+		{
+			"mDMEatX+cxYJKwYBBAHaRw8BAQdADeqyeCp1yG2ABZbRu21N5NBEMORnxGX+EC+E" +
+				"GEs4+M60IkdvbGRlbiBWZWN0b3IgPGdvbGRlbkBleGFtcGxlLmNvbT6IkAQTFggA" +
+				"OBYhBJlAA65lf5x+wOkgBtzLzjFTuo+0BQJq1f5zAhsDBQsJCAcCBhUKCQgLAgQW" +
+				"AgMBAh4BAheAAAoJENzLzjFTuo+00XgBAJizYAEf2qeCnc10ED1bpKNB/cO+/vNj" +
+				"n9b2W/bLMyAXAP0Q12y06qPSKTerMcc1qBScAVg505btElAqKDh3jZP2Bw==",
+			"iHUEABYIAB0WIQSZQAOuZX+cfsDpIAbcy84xU7qPtAUCatX+cwAKCRDcy84xU7qP" +
+				"tMSbAQDXeWNatY1C4PjekZ+vNgGXWTM3zCp/eymNrjiVWR6kwQD/TOXjeMykisB1" +
+				"J44Sv0XZcgMX/usjc1N615aFhYxuMAg=",
+		},
+		{
+			"xioGatX+dxsAAAAgSYCyCT10t9ufDRQUoMv7rECTcK8kG29UTcKTqWaqf9LClwYf" +
+				"GwgAAAA4BYJq1f53AgsHAhUIAhYAApsDAh4BIqEGf8ZenEt4delKqHxQRIsleWnM" +
+				"rajLz1Ftb2Kc96Kk4E0AAAAAdvUQoZxx7Lh544JkSI6Dbo1ZtXUaGqvzSUA9Q9mw" +
+				"aSxwL82SfwNg0pPhT0//Daao6j8GQeg8Ht0iV1HLVsMcANyBBISlZv5+R/Zxof5j" +
+				"d4UL2AfNIkdvbGRlbiBWZWN0b3IgPGdvbGRlbkBleGFtcGxlLmNvbT7CiwYTGwgA" +
+				"AAAsBYJq1f53AhkBIqEGf8ZenEt4delKqHxQRIsleWnMrajLz1Ftb2Kc96Kk4E0A" +
+				"AAAADmIQUQJibJm2MAmyv/AbpOUNzv6EB3Qoe2tmpg6nXCbqNH2yXmIapsMbkZ85" +
+				"5M9YydC0YmP0V9/FLpVLucaZhIIFWrAwX8ylgSIH09MAq9eHAgPOKgZq1f53GQAA" +
+				"ACAsc/PgLd60+DTuPyYpQ31U/UhAP3Ushmhm+KGFw2bzYMKLBhgbCAAAACwFgmrV" +
+				"/ncCmwwioQZ/xl6cS3h16UqofFBEiyV5acytqMvPUW1vYpz3oqTgTQAAAABw5xCE" +
+				"1H87Jc+2AFwccvoBVIEQck2az6ax1uSHSfUkdtwB6hg0BDwUc5BLvTU+9EBnR+6T" +
+				"+r74BPYgXb7UHXsTgrTpVhl+/IRNweBpRTBKWJp/Aw==",
+			"wogGABsIAAAAKQWCatX+dyKhBn/GXpxLeHXpSqh8UESLJXlpzK2oy89RbW9inPei" +
+				"pOBNAAAAAOW/ENLbWjAEXaBtU1TKdhv//JpGAcchUeqMlaykC4bcbo5VjesYC2fY" +
+				"0IoML1BUzxib/HsF764o9P1s8Ki/JTSxRSQrJF3yzw64Wu4uMlV9XFsP",
+		},
+	}
+	for _, vector := range vectors {
+		var key, _ = b64.StdEncoding.DecodeString(vector[0])
+		var signature, _ = b64.StdEncoding.DecodeString(vector[1])
+		ass.True(t, class.SignatureMatches(key, artifact, signature))
+		ass.False(t, class.SignatureMatches(key, []byte("A tampered artifact.\n"), signature))
+	}
+
+	// The keys from the two vectors are not interchangeable.
+	var key, _ = b64.StdEncoding.DecodeString(vectors[1][0])
+	var signature, _ = b64.StdEncoding.DecodeString(vectors[0][1])
+	ass.False(t, class.SignatureMatches(key, artifact, signature))
+}
+
+func TestOpenPGPTooling(t *tes.T) {
+	// The version 4 exports are also checked by GnuPG when it is installed.
+	var gpg, err = exe.LookPath("gpg")
+	if err != nil {
+		t.Skip("GnuPG is not installed.")
+	}
+	var directory = t.TempDir()
+	var artifact = []byte("A release artifact.\n")
+	notary.GenerateKey(identity.GetAttributes())
+	var key = notary.ExportPGPKey(4)
+	var signature = notary.ExportPGPSignature(artifact, 4)
+	notary.ForgetKey()
+	osx.WriteFile(directory+"/artifact", artifact, 0600)
+	osx.WriteFile(directory+"/artifact.sig", signature, 0600)
+	osx.WriteFile(directory+"/notary.key", key, 0600)
+	var environment = append(osx.Environ(), "GNUPGHOME="+directory)
+	var command = exe.Command(gpg, "--batch", "--import", directory+"/notary.key")
+	command.Env = environment
+	output, err := command.CombinedOutput()
+	ass.Nil(t, err, string(output))
+	command = exe.Command(
+		gpg, "--batch", "--verify", directory+"/artifact.sig", directory+"/artifact",
+	)
+	command.Env = environment
+	output, err = command.CombinedOutput()
+	ass.Nil(t, err, string(output))
+	ass.True(t, sts.Contains(string(output), "Good signature"))
+
+	// A tampered artifact is rejected by GnuPG as well.
+	osx.WriteFile(directory+"/artifact", []byte("A tampered artifact.\n"), 0600)
+	command = exe.Command(
+		gpg, "--batch", "--verify", directory+"/artifact.sig", directory+"/artifact",
+	)
+	command.Env = environment
+	output, err = command.CombinedOutput()
+	ass.NotNil(t, err, string(output))
+}
+
+func TestEvidenceBundles(t *tes.T) {
+	// Notarize and log a document and then rotate the notary key.
+	var directory = testDirectory + "bundles"
+	uti.RemovePath(directory)
+	var log = not.TransparencyLog(notary, not.SsmSha512(), directory)
+	notary.SetTransparencyLog(log)
+	var v1 = notary.GenerateKey(identity.GetAttributes())
+	var content = not.Content(
+		doc.Quote(`"Hello World!"`),
+		doc.Name("/bali/types/documents/Message/v3"),
+		doc.Tag(),
+		doc.Version(),
+		doc.Name("/bali/permissions/Public/v3"),
+		nil,
+	)
+	var document = not.Document(content)
+	notary.NotarizeDocument(document)
+	var treeHead = log.GetTreeHead()
+	var v2 = notary.RefreshKey()
+	notary.SetTransparencyLog(nil)
+
+	// The bundle contains everything needed to verify the document.
+	var repository = &repository_{documents_: []not.DocumentLike{v2, treeHead, v1}}
+	var bundle = notary.AssembleBundle(document, repository)
+	var evidence = not.Bundle(bundle.GetContent())
+	ass.Equal(t, document.AsSource(), evidence.GetDocument().AsSource())
+	ass.Equal(t, 2, len(evidence.GetCertificates()))
+	ass.Equal(t, v2.GetContent().GetVersion().AsSource(), evidence.GetStatus()[0].GetVersion().AsSource())
+	ass.Equal(t, 1, len(evidence.GetTimestamps()))
+	var trusted = []not.DocumentLike{v1}
+	ass.Equal(t, 0, len(notary.VerifyBundle(bundle, trusted)))
+	ass.Equal(t, 0, len(notary.VerifyBundle(bundle, []not.DocumentLike{v2})))
+	ass.Panics(t, func() { notary.VerifyBundle(bundle, nil) })
+
+	// A bundle without the certificate status is incomplete.
+	var incomplete = not.Bundle(document, doc.Tag(), doc.Version(), nil)
+	incomplete.AddCertificate(v1)
+	incomplete.AddTimestamp(treeHead)
+	var unverifiable = not.Document(incomplete)
+	notary.NotarizeDocument(unverifiable)
+	var violations = notary.VerifyBundle(unverifiable, trusted)
+	ass.Contains(t, violations, "The status of certificate "+v1.GetContent().GetTag().AsSource()+" is missing.")
+	ass.Contains(t, violations, "The certificate that sealed the bundle is missing.")
+	ass.Panics(t, func() { notary.AssembleBundle(document, &repository_{}) })
+
+	// The bundle is verified offline without any keys.
+	notary.ForgetKey()
+	bundle = not.Document(bundle.AsSource())
+	ass.Equal(t, 0, len(notary.VerifyBundle(bundle, trusted)))
+	var tampered = not.Document(sts.Replace(bundle.AsSource(), "Hello World!", "Goodbye World!", 1))
+	violations = notary.VerifyBundle(tampered, trusted)
+	ass.Contains(t, violations, "The seal on the bundle is invalid.")
+	ass.Contains(t, violations, "The seal on the document is invalid.")
+	ass.Panics(t, func() { notary.VerifyBundle(document, trusted) })
+
+	// A bundle that is assembled by a foreign notary is internally consistent
+	// but it is not trusted.
+	var forger = not.DigitalNotary(ssm, HsmEd25519TestClass().HsmEd25519("forger", secret))
+	var foreign = forger.GenerateKey(identity.GetAttributes())
+	var forgery = not.Document(content)
+	forger.NotarizeDocument(forgery)
+	var forged = forger.AssembleBundle(forgery, &repository_{documents_: []not.DocumentLike{foreign}})
+	forger.ForgetKey()
+	violations = notary.VerifyBundle(forged, trusted)
+	ass.Equal(t, []string{
+		"Certificate " + foreign.GetContent().GetTag().AsSource() + " is not trusted.",
+	}, violations)
+	ass.Equal(t, 0, len(notary.VerifyBundle(forged, []not.DocumentLike{foreign})))
+	violations = notary.VerifyBundle(bundle, []not.DocumentLike{foreign})
+	ass.Contains(t, violations, "Certificate "+v1.GetContent().GetTag().AsSource()+" is not trusted.")
+}
+
+func TestArchivalResealing(t *tes.T) {
+	// Notarize a document and then rotate the notary key.
+	var v1 = notary.GenerateKey(identity.GetAttributes())
+	var content = not.Content(
+		doc.Quote(`"Archive me!"`),
+		doc.Name("/bali/types/documents/Message/v3"),
+		doc.Tag(),
+		doc.Version(),
+		doc.Name("/bali/permissions/Public/v3"),
+		nil,
+	)
+	var document = not.Document(content)
+	notary.NotarizeDocument(document)
+	var v2 = notary.RefreshKey()
+	var repository = &repository_{documents_: []not.DocumentLike{v1, v2}}
+	var trusted = []not.DocumentLike{v2}
+	ass.Equal(t, 0, len(notary.VerifyArchive(document, repository, trusted)))
+
+	// Each reseal wraps the document and its existing seals in a new notary.
+	notary.ResealDocument(document, repository, trusted)
+	ass.True(t, notary.SealMatches(document, v2))
+	ass.Equal(t, 0, len(notary.VerifyArchive(document, repository, trusted)))
+	notary.ResealDocument(document, repository, trusted)
+	ass.Equal(t, 0, len(notary.VerifyArchive(document, repository, trusted)))
+	var layers = 0
+	for archived := not.Document(document.AsSource()); archived.IsNotarized(); layers++ {
+		archived.RemoveNotary()
+	}
+	ass.Equal(t, 3, layers)
+
+	// Any change to the document invalidates every seal.
+	var tampered = not.Document(sts.Replace(document.AsSource(), "Archive me!", "Forget me!", 1))
+	var violations = notary.VerifyArchive(tampered, repository, trusted)
+	ass.Equal(t, []string{
+		"The seal for notary 1 is invalid.",
+		"The seal for notary 2 is invalid.",
+		"The seal for notary 3 is invalid.",
+	}, violations)
+	ass.Panics(t, func() { notary.ResealDocument(tampered, repository, trusted) })
+
+	// The certificate for each seal is required.
+	violations = notary.VerifyArchive(document, &repository_{documents_: []not.DocumentLike{v2}}, trusted)
+	ass.Equal(t, []string{
+		"The certificate for notary 1 could not be retrieved.",
+		"The certificate chain for notary 2 is invalid.",
+		"The certificate chain for notary 3 is invalid.",
+	}, violations)
+	ass.Panics(t, func() { notary.ResealDocument(not.Document(content), repository, trusted) })
+
+	// A certificate that is not trusted does not protect the archive, even
+	// when it can be retrieved from the repository.
+	var archivist = not.DigitalNotary(ssm, HsmEd25519TestClass().HsmEd25519("archivist", secret))
+	var foreign = archivist.GenerateKey(identity.GetAttributes())
+	var forgery = not.Document(content)
+	archivist.NotarizeDocument(forgery)
+	archivist.ForgetKey()
+	var foreignRepository = &repository_{documents_: []not.DocumentLike{v1, v2, foreign}}
+	violations = notary.VerifyArchive(forgery, foreignRepository, trusted)
+	ass.Equal(t, []string{"The certificate for notary 1 is not trusted."}, violations)
+	ass.Panics(t, func() { notary.ResealDocument(forgery, foreignRepository, trusted) })
+	ass.Equal(t, 0, len(notary.VerifyArchive(forgery, foreignRepository, []not.DocumentLike{foreign})))
+
+	// A notary using different algorithms verifies the earlier seals using the
+	// legacy security modules for their algorithms.
+	var stronger = not.DigitalNotary(not.SsmSha3(), HsmP384TestClass().HsmP384())
+	var v3 = stronger.GenerateKey(identity.GetAttributes())
+	repository.documents_ = append(repository.documents_, v3)
+	trusted = append(trusted, v3)
+	ass.Panics(t, func() { stronger.ResealDocument(document, repository, trusted) })
+	var verifier = HsmEd25519TestClass().HsmEd25519("verifier", secret)
+	stronger.SetLegacyModules([]not.Trusted{not.SsmSha512()}, []not.Hardened{verifier})
+	stronger.ResealDocument(document, repository, trusted)
+	ass.True(t, stronger.SealMatches(document, v3))
+	ass.Equal(t, 0, len(stronger.VerifyArchive(document, repository, trusted)))
+	stronger.ForgetKey()
+
+	// Each layer is checked using the algorithms of its own certificate.
+	violations = notary.VerifyArchive(document, repository, trusted)
+	ass.Equal(t, []string{"Notary 4 uses the unsupported SHA3-512 digest algorithm."}, violations)
+	notary.SetLegacyModules([]not.Trusted{not.SsmSha3()}, nil)
+	violations = notary.VerifyArchive(document, repository, trusted)
+	ass.Equal(t, []string{"Notary 4 uses the unsupported ECDSA-P384 algorithm."}, violations)
+	notary.SetLegacyModules([]not.Trusted{not.SsmSha3()}, []not.Hardened{HsmP384TestClass().HsmP384()})
+	ass.Equal(t, 0, len(notary.VerifyArchive(document, repository, trusted)))
+	tampered = not.Document(sts.Replace(document.AsSource(), "Archive me!", "Forget me!", 1))
+	ass.Equal(t, 4, len(notary.VerifyArchive(tampered, repository, trusted)))
+	notary.SetLegacyModules(nil, nil)
+	notary.ForgetKey()
+}
+
+func TestContentStore(t *tes.T) {
+	// Store the certificates of the notary along with a notarized document.
+	var directory = testDirectory + "store"
+	uti.RemovePath(directory)
+	var store = not.ContentStore(ssm, directory)
+	var v1 = notary.GenerateKey(identity.GetAttributes())
+	var v2 = notary.RefreshKey()
+	var content = not.Content(
+		doc.Quote(`"Store me!"`),
+		doc.Name("/bali/types/documents/Message/v3"),
+		doc.Tag(),
+		doc.Version(),
+		doc.Name("/bali/permissions/Public/v3"),
+		nil,
+	)
+	var document = not.Document(content)
+	notary.NotarizeDocument(document)
+	store.StoreDocument(v1)
+	store.StoreDocument(v2)
+	var citation = store.StoreDocument(document)
+	ass.Equal(t, notary.CiteDocument(document).AsSource(), citation.AsSource())
+
+	// Identical documents are only stored once.
+	ass.Equal(t, citation.AsSource(), store.StoreDocument(not.Document(document.AsSource())).AsSource())
+	ass.Equal(t, uint(3), store.GetSize())
+	ass.Equal(t, document.AsSource(), store.RetrieveDocument(citation).AsSource())
+	ass.Equal(t, 2, len(store.RetrieveVersions(v1.GetContent().GetTag())))
+	var history = not.History(notary, store, v2)
+	ass.True(t, history.IsValid())
+
+	// Only the entries that can be reached from the roots are kept.
+	var orphan = not.Document(content)
+	notary.NotarizeDocument(orphan)
+	var orphanCitation = store.StoreDocument(orphan)
+	ass.Equal(t, uint(4), store.GetSize())
+	ass.Equal(t, uint(1), store.CollectGarbage([]not.CitationLike{citation}))
+	ass.Nil(t, store.RetrieveDocument(orphanCitation))
+	ass.NotNil(t, store.RetrieveDocument(notary.CiteDocument(v1)))
+	store = not.ContentStore(ssm, directory)
+	ass.Equal(t, uint(3), store.GetSize())
+	ass.Equal(t, 0, len(store.ScrubStore()))
+
+	// Any change to an entry is detected by a scrub.
+	var key = hex.EncodeToString(citation.GetDigest().AsIntrinsic())
+	var filename = directory + "/" + ssm.GetDigestAlgorithm() + "/" + key + ".bali"
+	var source = uti.ReadFile(filename)
+	uti.WriteFile(filename, sts.Replace(source, "Store me!", "Lose me!", 1))
+	ass.Equal(t, []string{"The entry " + key + " does not match its digest."}, store.ScrubStore())
+	ass.Panics(t, func() { store.RetrieveDocument(citation) })
+	uti.WriteFile(filename, source)
+
+	// Citations that digested an earlier format resolve to the stored document.
+	var legacy = not.Citation(
+		citation.GetTag(),
+		citation.GetVersion(),
+		citation.GetAlgorithm(),
+		doc.Binary(ssm.DigestBytes(not.CanonicalClass().FormatBytes(
+			document.AsIntrinsic(),
+			not.CanonicalClass().LegacyFormat(),
+		))),
+	)
+	var formatted = not.Citation(
+		citation.GetTag(),
+		citation.GetVersion(),
+		citation.GetAlgorithm(),
+		doc.Binary(ssm.DigestBytes([]byte(document.AsSource()))),
+	)
+	ass.Equal(t, document.AsSource(), store.RetrieveDocument(legacy).AsSource())
+	ass.Equal(t, document.AsSource(), store.RetrieveDocument(formatted).AsSource())
+	store = not.ContentStore(ssm, directory)
+	ass.Equal(t, document.AsSource(), store.RetrieveDocument(legacy).AsSource())
+
+	// A legacy root citation keeps the document and everything it cites.
+	ass.Equal(t, uint(0), store.CollectGarbage([]not.CitationLike{legacy}))
+	ass.Equal(t, uint(3), store.GetSize())
+	ass.Panics(t, func() { store.CollectGarbage(nil) })
+
+	// The roots may be found in any document that contains citations.
+	var references = not.ContentStoreClass().References(document.AsSource())
+	ass.Equal(t, 1, len(references))
+	ass.Equal(t, notary.CiteDocument(v2).AsSource(), references[0].AsSource())
+	references = not.ContentStoreClass().References(legacy.AsResource().AsSource())
+	ass.Equal(t, legacy.AsSource(), references[0].AsSource())
+	ass.Equal(t, uint(1), store.CollectGarbage(not.ContentStoreClass().References(document.AsSource())))
+	ass.Nil(t, store.RetrieveDocument(legacy))
+	notary.ForgetKey()
+}
@@ -0,0 +1,226 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package notarytest
+
+import (
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+// GLOBAL FUNCTIONS
+
+/*
+RunHardenedConformance runs the complete set of conformance tests against the
+hardened security modules returned by the specified factory.  The security
+module is left without any keys when the suite completes.
+*/
+func RunHardenedConformance(
+	t *tes.T,
+	factory HardenedFactory,
+) {
+	t.Run("Algorithm", func(t *tes.T) {
+		var hsm = factory()
+		ass.NotEmpty(t, hsm.GetSignatureAlgorithm())
+	})
+
+	t.Run("GenerateKeys", func(t *tes.T) {
+		var hsm = factory()
+		hsm.EraseKeys()
+		var publicKey = hsm.GenerateKeys()
+		ass.NotEmpty(t, publicKey)
+		ass.Equal(t, publicKey, hsm.GetPublicKey())
+		hsm.EraseKeys()
+	})
+
+	t.Run("SignAndVerify", func(t *tes.T) {
+		var hsm = factory()
+		hsm.EraseKeys()
+		var publicKey = hsm.GenerateKeys()
+		var bytes = []byte("The quick brown fox jumps over the lazy dog.")
+		var signature = hsm.SignBytes(bytes)
+		ass.True(t, hsm.IsValid(publicKey, bytes, signature))
+
+		// A modified message must not verify.
+		var modified = append([]byte{}, bytes...)
+		modified[0] ^= 0x01
+		ass.False(t, hsm.IsValid(publicKey, modified, signature))
+
+		// A modified signature must not verify.
+		var forged = append([]byte{}, signature...)
+		forged[0] ^= 0x01
+		ass.False(t, hsm.IsValid(publicKey, bytes, forged))
+
+		// A different key must not verify.
+		hsm.EraseKeys()
+		var otherKey = hsm.GenerateKeys()
+		ass.False(t, hsm.IsValid(otherKey, bytes, signature))
+		hsm.EraseKeys()
+	})
+
+	t.Run("KeyRotation", func(t *tes.T) {
+		var hsm = factory()
+		hsm.EraseKeys()
+		var previousKey = hsm.GenerateKeys()
+		ass.Empty(t, hsm.GetPreviousKey())
+		var currentKey = hsm.RotateKeys()
+		ass.NotEqual(t, previousKey, currentKey)
+		ass.Equal(t, currentKey, hsm.GetPublicKey())
+		ass.Equal(t, previousKey, hsm.GetPreviousKey())
+
+		// Only the previous key may be used while a rotation is pending.
+		ass.Panics(t, func() { hsm.SignBytes(currentKey) })
+		ass.Panics(t, func() { hsm.RotateKeys() })
+		var signature = hsm.SignWithPreviousKey(currentKey)
+		ass.True(t, hsm.IsValid(previousKey, currentKey, signature))
+		ass.False(t, hsm.IsValid(currentKey, currentKey, signature))
+
+		// The previous key may only be used once.
+		ass.Empty(t, hsm.GetPreviousKey())
+		ass.Panics(t, func() { hsm.SignWithPreviousKey(currentKey) })
+		var bytes = []byte("Signed with the current key.")
+		signature = hsm.SignBytes(bytes)
+		ass.True(t, hsm.IsValid(currentKey, bytes, signature))
+		hsm.EraseKeys()
+	})
+
+	t.Run("AbortRotation", func(t *tes.T) {
+		var hsm = factory()
+		hsm.EraseKeys()
+		var previousKey = hsm.GenerateKeys()
+		hsm.RotateKeys()
+
+		// Aborting the rotation must reinstate the previous key pair.
+		ass.Equal(t, previousKey, hsm.AbortRotation())
+		ass.Equal(t, previousKey, hsm.GetPublicKey())
+		ass.Empty(t, hsm.GetPreviousKey())
+		ass.Panics(t, func() { hsm.SignWithPreviousKey(previousKey) })
+		ass.Panics(t, func() { hsm.AbortRotation() })
+		var bytes = []byte("Signed with the reinstated key.")
+		var signature = hsm.SignBytes(bytes)
+		ass.True(t, hsm.IsValid(previousKey, bytes, signature))
+		hsm.EraseKeys()
+	})
+
+	t.Run("RotationRecord", func(t *tes.T) {
+		var hsm = factory()
+		hsm.EraseKeys()
+		var previousKey = hsm.GenerateKeys()
+		var record = []byte("The new certificate.")
+		ass.Panics(t, func() { hsm.RecordRotation(record) })
+
+		// The record and signature of a rotation must survive a restart.
+		hsm.RotateKeys()
+		ass.Empty(t, hsm.GetRotationRecord())
+		hsm.RecordRotation(record)
+		hsm = factory()
+		ass.Equal(t, record, hsm.GetRotationRecord())
+		ass.Empty(t, hsm.GetRotationSignature())
+		var signature = hsm.SignWithPreviousKey(record)
+		hsm = factory()
+		ass.Equal(t, record, hsm.GetRotationRecord())
+		ass.Equal(t, signature, hsm.GetRotationSignature())
+		ass.True(t, hsm.IsValid(previousKey, record, signature))
+		ass.Panics(t, func() { hsm.RecordRotation(record) })
+
+		// The next rotation replaces the record and an aborted rotation
+		// discards it.
+		hsm.RotateKeys()
+		ass.Empty(t, hsm.GetRotationRecord())
+		ass.Empty(t, hsm.GetRotationSignature())
+		hsm.RecordRotation(record)
+		hsm.AbortRotation()
+		ass.Empty(t, hsm.GetRotationRecord())
+		hsm.EraseKeys()
+	})
+
+	t.Run("EraseKeys", func(t *tes.T) {
+		var hsm = factory()
+		hsm.EraseKeys()
+		hsm.GenerateKeys()
+		hsm.EraseKeys()
+		ass.Empty(t, hsm.GetPublicKey())
+		ass.Panics(t, func() { hsm.SignBytes([]byte("No keys.")) })
+
+		// Erasing the keys must also cancel a pending rotation.
+		hsm.GenerateKeys()
+		hsm.RotateKeys()
+		hsm.EraseKeys()
+		ass.Empty(t, hsm.GetPublicKey())
+		ass.Panics(t, func() { hsm.SignWithPreviousKey([]byte("No keys.")) })
+
+		// New keys may be generated once the old ones have been erased.
+		ass.NotEmpty(t, hsm.GenerateKeys())
+		hsm.EraseKeys()
+	})
+
+	t.Run("InvalidStates", func(t *tes.T) {
+		var hsm = factory()
+		var bytes = []byte("Invalid state.")
+		hsm.EraseKeys()
+		ass.Panics(t, func() { hsm.SignBytes(bytes) })
+		ass.Panics(t, func() { hsm.RotateKeys() })
+		ass.Panics(t, func() { hsm.SignWithPreviousKey(bytes) })
+		ass.Panics(t, func() { hsm.AbortRotation() })
+		hsm.GenerateKeys()
+		ass.Panics(t, func() { hsm.GenerateKeys() })
+		ass.Panics(t, func() { hsm.SignWithPreviousKey(bytes) })
+		ass.Panics(t, func() { hsm.AbortRotation() })
+		hsm.RotateKeys()
+		ass.Panics(t, func() { hsm.GenerateKeys() })
+		hsm.EraseKeys()
+	})
+
+	t.Run("Persistence", func(t *tes.T) {
+		var hsm = factory()
+		hsm.EraseKeys()
+		var previousKey = hsm.GenerateKeys()
+
+		// The key pair must survive a restart.
+		hsm = factory()
+		ass.Equal(t, previousKey, hsm.GetPublicKey())
+		var bytes = []byte("Signed after a restart.")
+		var signature = hsm.SignBytes(bytes)
+		ass.True(t, hsm.IsValid(previousKey, bytes, signature))
+
+		// A pending rotation must survive a restart.
+		var currentKey = hsm.RotateKeys()
+		hsm = factory()
+		ass.Equal(t, currentKey, hsm.GetPublicKey())
+		ass.Equal(t, previousKey, hsm.GetPreviousKey())
+		ass.Panics(t, func() { hsm.SignBytes(bytes) })
+		signature = hsm.SignWithPreviousKey(currentKey)
+		ass.True(t, hsm.IsValid(previousKey, currentKey, signature))
+
+		// The completed rotation must survive a restart.
+		hsm = factory()
+		ass.Equal(t, currentKey, hsm.GetPublicKey())
+		ass.Empty(t, hsm.GetPreviousKey())
+		ass.Panics(t, func() { hsm.SignWithPreviousKey(bytes) })
+		signature = hsm.SignBytes(bytes)
+		ass.True(t, hsm.IsValid(currentKey, bytes, signature))
+
+		// An aborted rotation must survive a restart.
+		hsm.RotateKeys()
+		hsm = factory()
+		hsm.AbortRotation()
+		hsm = factory()
+		ass.Equal(t, currentKey, hsm.GetPublicKey())
+		ass.Empty(t, hsm.GetPreviousKey())
+
+		// Erased keys must stay erased after a restart.
+		hsm.EraseKeys()
+		hsm = factory()
+		ass.Empty(t, hsm.GetPublicKey())
+		ass.Panics(t, func() { hsm.SignBytes(bytes) })
+	})
+}
@@ -0,0 +1,58 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package notarytest
+
+import (
+	ass "github.com/stretchr/testify/assert"
+	tes "testing"
+)
+
+// GLOBAL FUNCTIONS
+
+/*
+RunTrustedConformance runs the complete set of conformance tests against the
+trusted security modules returned by the specified factory.
+*/
+func RunTrustedConformance(
+	t *tes.T,
+	factory TrustedFactory,
+) {
+	t.Run("Algorithm", func(t *tes.T) {
+		var ssm = factory()
+		ass.NotEmpty(t, ssm.GetDigestAlgorithm())
+		ass.Equal(t, ssm.GetDigestAlgorithm(), factory().GetDigestAlgorithm())
+	})
+
+	t.Run("DigestBytes", func(t *tes.T) {
+		var ssm = factory()
+		var bytes = []byte("The quick brown fox jumps over the lazy dog.")
+		var digest = ssm.DigestBytes(bytes)
+		ass.NotEmpty(t, digest)
+
+		// Digests must be deterministic across instances.
+		ass.Equal(t, digest, ssm.DigestBytes(bytes))
+		ass.Equal(t, digest, factory().DigestBytes(bytes))
+
+		// Digests must have a fixed length.
+		ass.Equal(t, len(digest), len(ssm.DigestBytes([]byte{})))
+		ass.Equal(t, len(digest), len(ssm.DigestBytes(make([]byte, 4096))))
+
+		// Different inputs must result in different digests.
+		var modified = append([]byte{}, bytes...)
+		modified[0] ^= 0x01
+		ass.NotEqual(t, digest, ssm.DigestBytes(modified))
+
+		// The input must not be modified.
+		ass.Equal(t, "The quick brown fox jumps over the lazy dog.", string(bytes))
+	})
+}
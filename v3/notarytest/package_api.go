@@ -0,0 +1,54 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+/*
+Package "notarytest" provides conformance test suites that may be run against
+any concrete implementation of the Hardened and Trusted security module aspects
+declared by the agents package.  A third party security module passes the suite
+by calling the corresponding function from within one of its own tests:
+
+	func TestConformance(t *testing.T) {
+		notarytest.RunHardenedConformance(t, func() agents.Hardened {
+			return MyHsmClass().MyHsm(device)
+		})
+	}
+
+For detailed documentation on this package refer to the wiki:
+  - https://github.com/bali-nebula/go-digital-notary/wiki
+
+This package follows the Crater Dog Technologies™ Go Coding Conventions located
+here:
+  - https://github.com/craterdog/go-development-tools/wiki/Coding-Conventions
+*/
+package notarytest
+
+import (
+	age "github.com/bali-nebula/go-digital-notary/v3/agents"
+)
+
+// TYPE DECLARATIONS
+
+// FUNCTIONAL DECLARATIONS
+
+/*
+HardenedFactory is a function type that returns an instance of the hardened
+security module being tested.  Each call must return a new instance that is
+attached to the same persistent storage as all previous instances so that the
+suite can verify that the state of the module survives a restart.
+*/
+type HardenedFactory func() age.Hardened
+
+/*
+TrustedFactory is a function type that returns a new instance of the trusted
+security module being tested.
+*/
+type TrustedFactory func() age.Trusted
@@ -0,0 +1,232 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package server
+
+import (
+	b64 "encoding/base64"
+	fmt "fmt"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	age "github.com/bali-nebula/go-digital-notary/v3/agents"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	iox "io"
+	htt "net/http"
+	sts "strings"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func ClientClass() ClientClassLike {
+	return clientClass()
+}
+
+// Constructor Methods
+
+func (c *clientClass_) Client(
+	url string,
+	optionalNotary age.DigitalNotaryLike,
+) ClientLike {
+	if uti.IsUndefined(url) {
+		panic("The \"url\" attribute is required by this class.")
+	}
+	var instance = &client_{
+		// Initialize the instance attributes.
+		url_:    sts.TrimSuffix(url, "/"),
+		notary_: optionalNotary,
+	}
+	return instance
+}
+
+// Constant Methods
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *client_) GetClass() ClientClassLike {
+	return clientClass()
+}
+
+func (v *client_) GetCertificate() com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to retrieve the certificate",
+	)
+
+	var source = v.sendRequest(htt.MethodGet, "/certificate", "", false)
+	return com.DocumentClass().DocumentFromSource(source)
+}
+
+func (v *client_) CiteDocument(
+	document com.DocumentLike,
+) com.CitationLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to cite a document",
+	)
+
+	var source = v.sendRequest(htt.MethodPost, "/cite", document.AsSource(), false)
+	return com.CitationClass().CitationFromSource(source)
+}
+
+func (v *client_) SealMatches(
+	document com.DocumentLike,
+	certificate com.DocumentLike,
+) bool {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to match a document seal",
+	)
+
+	var request = doc.ParseComponent(`[
+    $document: none
+    $certificate: none
+]`)
+	request.SetSubcomponent(document.AsIntrinsic(), doc.Symbol("$document"))
+	request.SetSubcomponent(certificate.AsIntrinsic(), doc.Symbol("$certificate"))
+	var source = v.sendRequest(
+		htt.MethodPost,
+		"/verify",
+		doc.FormatComponent(request),
+		false,
+	)
+	return doc.Boolean(source).AsIntrinsic()
+}
+
+func (v *client_) NotarizeDocument(
+	document com.DocumentLike,
+) {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to notarize a document",
+	)
+
+	// Add the notary that was added by the service to the original document.
+	var source = v.sendRequest(htt.MethodPost, "/notarize", document.AsSource(), true)
+	var notarized = com.DocumentClass().DocumentFromSource(source)
+	document.AddNotary(notarized.RemoveNotary())
+}
+
+func (v *client_) GenerateCredential(
+	context any,
+) com.DocumentLike {
+	// Check for any errors at the end.
+	defer v.errorCheck(
+		"An error occurred while attempting to generate a security credential",
+	)
+
+	var source = v.sendRequest(
+		htt.MethodPost,
+		"/credentials",
+		doc.FormatComponent(context),
+		true,
+	)
+	return com.DocumentClass().DocumentFromSource(source)
+}
+
+// Attribute Methods
+
+func (v *client_) GetURL() string {
+	return v.url_
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *client_) errorCheck(
+	message string,
+) {
+	if e := recover(); e != nil {
+		message = fmt.Sprintf(
+			"Client: %s:\n    %v",
+			message,
+			e,
+		)
+		panic(message)
+	}
+}
+
+func (v *client_) sendRequest(
+	method string,
+	path string,
+	body string,
+	authenticated bool,
+) string {
+	var request, err = htt.NewRequest(method, v.url_+path, sts.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+	var class = serverClass()
+	request.Header.Set("Content-Type", class.mediaType_)
+	request.Header.Set("Accept", class.mediaType_)
+
+	// Authenticate the request using a credential for this request only.
+	if authenticated {
+		if uti.IsUndefined(v.notary_) {
+			panic("A local digital notary is required to authenticate this request.")
+		}
+		var context = class.context(method, path, []byte(body))
+		var credential = v.notary_.GenerateCredential(context)
+		var encoded = b64.StdEncoding.EncodeToString([]byte(credential.AsSource()))
+		request.Header.Set("Authorization", class.scheme_+" "+encoded)
+	}
+
+	// Send the request and return the body of the response.
+	response, err := htt.DefaultClient.Do(request)
+	if err != nil {
+		panic(err)
+	}
+	defer response.Body.Close()
+	bytes, err := iox.ReadAll(response.Body)
+	if err != nil {
+		panic(err)
+	}
+	if response.StatusCode != htt.StatusOK {
+		var message = fmt.Sprintf(
+			"The service returned %s: %s",
+			response.Status,
+			sts.TrimSpace(string(bytes)),
+		)
+		panic(message)
+	}
+	return string(bytes)
+}
+
+// Instance Structure
+
+type client_ struct {
+	// Declare the instance attributes.
+	url_    string
+	notary_ age.DigitalNotaryLike
+}
+
+// Class Structure
+
+type clientClass_ struct {
+	// Declare the class constants.
+}
+
+// Class Reference
+
+func clientClass() *clientClass_ {
+	return clientClassReference_
+}
+
+var clientClassReference_ = &clientClass_{
+	// Initialize the class constants.
+}
@@ -0,0 +1,374 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+package server
+
+import (
+	sha "crypto/sha512"
+	b64 "encoding/base64"
+	doc "github.com/bali-nebula/go-bali-documents/v3"
+	age "github.com/bali-nebula/go-digital-notary/v3/agents"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	uti "github.com/craterdog/go-essential-utilities/v8"
+	iox "io"
+	htt "net/http"
+	sts "strings"
+	syn "sync"
+)
+
+// CLASS INTERFACE
+
+// Access Function
+
+func ServerClass() ServerClassLike {
+	return serverClass()
+}
+
+// Constructor Methods
+
+func (c *serverClass_) Server(
+	notary age.DigitalNotaryLike,
+	repository age.Resolving,
+) ServerLike {
+	if uti.IsUndefined(notary) {
+		panic("The \"notary\" attribute is required by this class.")
+	}
+	if uti.IsUndefined(repository) {
+		panic("The \"repository\" attribute is required by this class.")
+	}
+	var instance = &server_{
+		// Initialize the instance attributes.
+		notary_:     notary,
+		repository_: repository,
+		mux_:        htt.NewServeMux(),
+		nonces_:     make(map[string]int),
+	}
+	instance.mux_.HandleFunc("GET /certificate", instance.getCertificate)
+	instance.mux_.HandleFunc("POST /cite", instance.postCite)
+	instance.mux_.HandleFunc("POST /verify", instance.postVerify)
+	instance.mux_.HandleFunc("POST /notarize", instance.postNotarize)
+	instance.mux_.HandleFunc("POST /credentials", instance.postCredentials)
+	return instance
+}
+
+// Constant Methods
+
+func (c *serverClass_) MediaType() string {
+	return c.mediaType_
+}
+
+func (c *serverClass_) Scheme() string {
+	return c.scheme_
+}
+
+// Function Methods
+
+// INSTANCE INTERFACE
+
+// Principal Methods
+
+func (v *server_) GetClass() ServerClassLike {
+	return serverClass()
+}
+
+// Attribute Methods
+
+func (v *server_) GetNotary() age.DigitalNotaryLike {
+	return v.notary_
+}
+
+// Handler Methods
+
+func (v *server_) ServeHTTP(
+	writer htt.ResponseWriter,
+	request *htt.Request,
+) {
+	// The digital notary handles one request at a time.
+	v.mutex_.Lock()
+	defer v.mutex_.Unlock()
+
+	// Any invalid request results in a panic whose details are not revealed to
+	// the client.  A response cannot be replaced once it has been started.
+	v.responding_ = false
+	defer func() {
+		if e := recover(); e != nil && !v.responding_ {
+			var message = "The request could not be processed."
+			htt.Error(writer, message, htt.StatusBadRequest)
+		}
+	}()
+	v.mux_.ServeHTTP(writer, request)
+}
+
+// PROTECTED INTERFACE
+
+// Private Methods
+
+func (v *server_) authenticate(
+	request *htt.Request,
+	body []byte,
+) (authenticated bool) {
+	// Any malformed credential fails to authenticate.
+	defer func() {
+		if e := recover(); e != nil {
+			authenticated = false
+		}
+	}()
+
+	// Extract the credential from the authorization header.
+	var class = serverClass()
+	var header = request.Header.Get("Authorization")
+	var prefix = class.scheme_ + " "
+	if !sts.HasPrefix(header, prefix) {
+		return false
+	}
+	var bytes, err = b64.StdEncoding.DecodeString(sts.TrimPrefix(header, prefix))
+	if err != nil {
+		return false
+	}
+	var credential = com.DocumentClass().DocumentFromSource(string(bytes))
+	var content = credential.GetContent()
+	if content.GetType().AsSource() != "/bali/types/notary/Credential/v3" {
+		return false
+	}
+
+	// The credential must have been generated for this request and its body.
+	var context = class.context(request.Method, request.URL.Path, body)
+	if doc.FormatComponent(content.GetLiteral()) != doc.FormatComponent(context) {
+		return false
+	}
+	if !credential.IsNotarized() {
+		return false
+	}
+	var component = credential.GetSubcomponent(
+		doc.Symbol("$notaries"),
+		-1, // The last notary seal.
+	)
+	var notary = com.NotaryClass().NotaryFromSource(
+		doc.FormatComponent(component),
+	)
+	var timestamp = notary.GetTimestamp().AsIntrinsic()
+	var now = doc.Moment()
+	var earliest = doc.MomentClass().Earlier(now, class.maximumAge_).AsIntrinsic()
+	if timestamp < earliest || timestamp > now.AsIntrinsic() {
+		return false
+	}
+
+	// The credential must be notarized by a known certificate other than the
+	// certificate of the service itself.
+	var citation = credential.GetNotaryCitation()
+	if uti.IsUndefined(citation) {
+		return false
+	}
+	var own = v.notary_.GetOptionalCertificate()
+	if uti.IsDefined(own) &&
+		own.GetContent().GetTag().AsSource() == citation.GetTag().AsSource() {
+		return false
+	}
+	var certificate = v.repository_.RetrieveDocument(citation)
+	if uti.IsUndefined(certificate) {
+		return false
+	}
+	if !v.notary_.CitationMatches(citation, certificate) ||
+		!v.notary_.SealMatches(credential, certificate) {
+		return false
+	}
+
+	// Each credential may only be used once while it has not yet expired.
+	for nonce, moment := range v.nonces_ {
+		if moment < earliest {
+			delete(v.nonces_, nonce)
+		}
+	}
+	var nonce = content.GetTag().AsSource()
+	if _, ok := v.nonces_[nonce]; ok {
+		return false
+	}
+	v.nonces_[nonce] = timestamp
+	return true
+}
+
+func (c *serverClass_) context(
+	method string,
+	path string,
+	body []byte,
+) doc.Composite {
+	var digest = sha.Sum512(body)
+	var context = doc.ParseComponent(`[
+    $request: ""
+    $digest: none
+]`)
+	context.SetSubcomponent(
+		doc.QuoteClass().Quote([]rune(method+" "+path)),
+		doc.Symbol("$request"),
+	)
+	context.SetSubcomponent(doc.Binary(digest[:]), doc.Symbol("$digest"))
+	return context
+}
+
+func (v *server_) getCertificate(
+	writer htt.ResponseWriter,
+	request *htt.Request,
+) {
+	var certificate = v.notary_.GetOptionalCertificate()
+	if uti.IsUndefined(certificate) {
+		var message = "The digital notary has not yet been initialized."
+		htt.Error(writer, message, htt.StatusServiceUnavailable)
+		return
+	}
+	v.writeSource(writer, request, certificate.AsSource())
+}
+
+func (v *server_) postCite(
+	writer htt.ResponseWriter,
+	request *htt.Request,
+) {
+	var body = v.readBody(request)
+	var document = com.DocumentClass().DocumentFromSource(v.readSource(request, body))
+	var citation = v.notary_.CiteDocument(document)
+	v.writeSource(writer, request, citation.AsSource())
+}
+
+func (v *server_) postCredentials(
+	writer htt.ResponseWriter,
+	request *htt.Request,
+) {
+	var body = v.readBody(request)
+	if !v.authenticate(request, body) {
+		v.writeUnauthorized(writer)
+		return
+	}
+	var context = doc.ParseComponent(v.readSource(request, body))
+	var credential = v.notary_.GenerateCredential(context)
+	v.writeSource(writer, request, credential.AsSource())
+}
+
+func (v *server_) postNotarize(
+	writer htt.ResponseWriter,
+	request *htt.Request,
+) {
+	var body = v.readBody(request)
+	if !v.authenticate(request, body) {
+		v.writeUnauthorized(writer)
+		return
+	}
+	var document = com.DocumentClass().DocumentFromSource(v.readSource(request, body))
+	v.notary_.NotarizeDocument(document)
+	v.writeSource(writer, request, document.AsSource())
+}
+
+func (v *server_) postVerify(
+	writer htt.ResponseWriter,
+	request *htt.Request,
+) {
+	var body = v.readBody(request)
+	var component = doc.ParseComponent(v.readSource(request, body))
+	var document = com.DocumentClass().DocumentFromSource(
+		doc.FormatComponent(component.GetSubcomponent(doc.Symbol("$document"))),
+	)
+	var certificate = com.DocumentClass().DocumentFromSource(
+		doc.FormatComponent(component.GetSubcomponent(doc.Symbol("$certificate"))),
+	)
+	var matches = v.notary_.SealMatches(document, certificate)
+	v.writeSource(writer, request, doc.Boolean(matches).AsSource())
+}
+
+func (v *server_) readBody(
+	request *htt.Request,
+) []byte {
+	var class = serverClass()
+	var reader = iox.LimitReader(request.Body, class.maximumSize_)
+	var bytes, err = iox.ReadAll(reader)
+	if err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func (v *server_) readSource(
+	request *htt.Request,
+	body []byte,
+) string {
+	var class = serverClass()
+	var source = string(body)
+
+	// A JSON body is converted into its Bali source.
+	if sts.HasPrefix(request.Header.Get("Content-Type"), class.jsonType_) {
+		var component = com.JSONClass().ParseJSON(source)
+		source = doc.FormatComponent(component)
+	}
+	return source
+}
+
+func (v *server_) writeSource(
+	writer htt.ResponseWriter,
+	request *htt.Request,
+	source string,
+) {
+	// A JSON response is returned if the client accepts it.
+	var class = serverClass()
+	var mediaType = class.mediaType_
+	if sts.Contains(request.Header.Get("Accept"), class.jsonType_) {
+		mediaType = class.jsonType_
+		source = com.JSONClass().FormatJSON(doc.ParseComponent(source))
+	}
+	v.responding_ = true
+	writer.Header().Set("Content-Type", mediaType)
+	writer.WriteHeader(htt.StatusOK)
+	iox.WriteString(writer, source)
+}
+
+func (v *server_) writeUnauthorized(
+	writer htt.ResponseWriter,
+) {
+	v.responding_ = true
+	writer.Header().Set("WWW-Authenticate", serverClass().scheme_)
+	var message = "A valid credential is required for this request."
+	htt.Error(writer, message, htt.StatusUnauthorized)
+}
+
+// Instance Structure
+
+type server_ struct {
+	// Declare the instance attributes.
+	notary_     age.DigitalNotaryLike
+	repository_ age.Resolving
+	mux_        *htt.ServeMux
+	mutex_      syn.Mutex
+	responding_ bool
+	nonces_     map[string]int
+}
+
+// Class Structure
+
+type serverClass_ struct {
+	// Declare the class constants.
+	mediaType_   string
+	jsonType_    string
+	scheme_      string
+	maximumAge_  doc.DurationLike
+	maximumSize_ int64
+}
+
+// Class Reference
+
+func serverClass() *serverClass_ {
+	return serverClassReference_
+}
+
+var serverClassReference_ = &serverClass_{
+	// Initialize the class constants.
+	mediaType_:   "application/bali",
+	jsonType_:    "application/json",
+	scheme_:      "Credential",
+	maximumAge_:  doc.Duration("~PT5M"),
+	maximumSize_: 1 << 20, // One megabyte.
+}
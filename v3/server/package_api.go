@@ -0,0 +1,145 @@
+/*
+................................................................................
+.    Copyright (c) 2009-2026 Crater Dog Technologies™.  All Rights Reserved.   .
+................................................................................
+.  DO NOT ALTER OR REMOVE COPYRIGHT NOTICES OR THIS FILE HEADER.               .
+.                                                                              .
+.  This code is free software; you can redistribute it and/or modify it under  .
+.  the terms of The MIT License (MIT), as published by the Open Source         .
+.  Initiative. (See https://opensource.org/license/MIT)                        .
+................................................................................
+*/
+
+/*
+Package "server" provides an HTTP service that exposes a single digital notary
+to clients written in any language, and a client that mirrors the digital notary
+interface for use by Go programs.  The service supports the following endpoints,
+each of which exchanges Bali documents as "application/bali" bodies:
+
+	GET  /certificate   returns the current certificate of the notary
+	POST /cite          returns a citation to the document in the body
+	POST /verify        checks the seal on a $document using a $certificate
+	POST /notarize      notarizes the document in the body
+	POST /credentials   generates a credential for the context in the body
+
+Any request body may instead be sent as "application/json" and a JSON response
+is returned to any client that accepts "application/json".  The JSON form of
+each document is defined by the JSON class of the components package.
+
+The notarize and credentials endpoints require the caller to authenticate using
+a credential that was generated by its own digital notary for that request.  The
+credential is passed in the "Authorization" header and its context binds it to
+the method, path and SHA512 digest of the request body:
+
+	[
+	    $request: "POST /notarize"
+	    $digest: '...'
+	]
+
+The credential must be notarized by a certificate other than that of the service
+that is known to the repository of the service, and it may only be used once.
+The details of any other failed request are not revealed to the client.
+
+For detailed documentation on this package refer to the wiki:
+  - https://github.com/bali-nebula/go-digital-notary/wiki
+
+This package follows the Crater Dog Technologies™ Go Coding Conventions located
+here:
+  - https://github.com/craterdog/go-development-tools/wiki/Coding-Conventions
+*/
+package server
+
+import (
+	age "github.com/bali-nebula/go-digital-notary/v3/agents"
+	com "github.com/bali-nebula/go-digital-notary/v3/components"
+	htt "net/http"
+)
+
+// TYPE DECLARATIONS
+
+// FUNCTIONAL DECLARATIONS
+
+// CLASS DECLARATIONS
+
+/*
+ClientClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+client-like class.
+
+A client sends requests to a digital notary service at the specified URL.  The
+optional local digital notary is used to generate the credentials that are
+required by the authenticated endpoints.
+*/
+type ClientClassLike interface {
+	// Constructor Methods
+	Client(
+		url string,
+		optionalNotary age.DigitalNotaryLike,
+	) ClientLike
+}
+
+/*
+ServerClassLike is a class interface that declares the complete set of class
+constructors, constants and functions that must be supported by each concrete
+server-like class.
+
+A server handles the HTTP requests for a digital notary service.  The repository
+is used to retrieve the certificates of the clients that authenticate with it.
+*/
+type ServerClassLike interface {
+	// Constructor Methods
+	Server(
+		notary age.DigitalNotaryLike,
+		repository age.Resolving,
+	) ServerLike
+
+	// Constant Methods
+	MediaType() string
+	Scheme() string
+}
+
+// INSTANCE DECLARATIONS
+
+/*
+ClientLike is an instance interface that declares the complete set of principal,
+attribute and aspect methods that must be supported by each instance of a
+concrete client-like class.  Each method mirrors the corresponding method of a
+digital notary but is performed by the remote service.
+*/
+type ClientLike interface {
+	// Principal Methods
+	GetClass() ClientClassLike
+	GetCertificate() com.DocumentLike
+	CiteDocument(
+		document com.DocumentLike,
+	) com.CitationLike
+	SealMatches(
+		document com.DocumentLike,
+		certificate com.DocumentLike,
+	) bool
+	NotarizeDocument(
+		document com.DocumentLike,
+	)
+	GenerateCredential(
+		context any,
+	) com.DocumentLike
+
+	// Attribute Methods
+	GetURL() string
+}
+
+/*
+ServerLike is an instance interface that declares the complete set of principal,
+attribute and aspect methods that must be supported by each instance of a
+concrete server-like class.
+*/
+type ServerLike interface {
+	// Principal Methods
+	GetClass() ServerClassLike
+
+	// Attribute Methods
+	GetNotary() age.DigitalNotaryLike
+
+	// Aspect Interfaces
+	htt.Handler
+}